@@ -2,13 +2,19 @@
 //
 // Command-line options:
 //
-//	--start-level N, -l N    Start the game at level N (1-4) for development/testing
+//	--start-level N, -l N    Start the game at level N of the pack for development/testing
+//	--seed N                 Generate every level from seed N to reproduce a run
+//	--levels FILE            Play the level pack in FILE (.json, .yaml or .yml) instead of the built-in levels
+//	--difficulty NAME        Start at the easy, normal, hard or custom difficulty (custom:hearts=5,timer=1.2,...)
 //
 // Examples:
 //
 //	go run ./cmd/main                    # Start at level 1 (normal gameplay)
 //	go run ./cmd/main --start-level 3    # Start at level 3 (development mode)
 //	go run ./cmd/main -l 2               # Start at level 2 (development mode)
+//	go run ./cmd/main --seed 42 -l 3     # Reproduce level 3 of the run with seed 42
+//	go run ./cmd/main --levels pack.yaml # Play a custom level pack
+//	go run ./cmd/main --difficulty hard  # Play with fewer hearts against more hazards
 package main
 
 import (
@@ -21,12 +27,16 @@ import (
 	"github.com/juanancid/maze-adventure/internal/app"
 	engineconfig "github.com/juanancid/maze-adventure/internal/engine/config"
 	gameplayconfig "github.com/juanancid/maze-adventure/internal/gameplay/config"
+	"github.com/juanancid/maze-adventure/internal/gameplay/levels"
 )
 
 func main() {
 	// Parse command-line arguments
-	startLevel := flag.Int("start-level", 1, "Starting level (1-4)")
-	startLevelShort := flag.Int("l", 1, "Starting level (1-4) - short form")
+	startLevel := flag.Int("start-level", 1, "Starting level")
+	startLevelShort := flag.Int("l", 1, "Starting level - short form")
+	seed := flag.Int64("seed", 0, "Seed for level generation (0 = random)")
+	levelsPath := flag.String("levels", "", "Level pack file (.json, .yaml or .yml), empty uses the built-in levels")
+	difficultyName := flag.String("difficulty", gameplayconfig.DifficultyNormal, "Difficulty: easy, normal, hard or custom:hearts=N,timer=X,speed=X,damage=N,freeze=D,cooldown=D,hazards=X")
 	flag.Parse()
 
 	// Use the short form if provided, otherwise use the long form
@@ -35,13 +45,35 @@ func main() {
 		selectedLevel = *startLevelShort
 	}
 
+	pack := levels.DefaultPack()
+	if *levelsPath != "" {
+		var err error
+		pack, err = levels.LoadPack(*levelsPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: Invalid level pack:\n%v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// Validate level number
-	if selectedLevel < 1 || selectedLevel > 4 {
-		fmt.Fprintf(os.Stderr, "Error: Invalid level number %d. Must be between 1 and 4.\n", selectedLevel)
+	if selectedLevel < 1 || selectedLevel > len(pack.Levels) {
+		fmt.Fprintf(os.Stderr, "Error: Invalid level number %d. Must be between 1 and %d.\n", selectedLevel, len(pack.Levels))
 		fmt.Fprintf(os.Stderr, "Usage: %s [--start-level N] or [--l N]\n", os.Args[0])
 		os.Exit(1)
 	}
 
+	difficulty, err := gameplayconfig.ParseDifficulty(*difficultyName)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Invalid difficulty: %v\n", err)
+		os.Exit(1)
+	}
+
+	// The custom difficulty is offered in the title menu even when starting at a preset
+	customDifficulty := difficulty
+	if difficulty.Name != gameplayconfig.DifficultyCustom {
+		customDifficulty, _ = gameplayconfig.ParseDifficulty(gameplayconfig.DifficultyCustom)
+	}
+
 	if selectedLevel > 1 {
 		fmt.Printf("Starting game at level %d (development mode)\n", selectedLevel)
 	}
@@ -51,11 +83,13 @@ func main() {
 	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeOnlyFullscreenEnabled)
 
 	gameConfig := gameplayconfig.GameConfig{
-		StartingHearts: 3,
-		StartingLevel:  selectedLevel,
+		Difficulty:       difficulty,
+		CustomDifficulty: customDifficulty,
+		StartingLevel:    selectedLevel,
+		Seed:             *seed,
 	}
 
-	g := app.NewGame(gameConfig)
+	g := app.NewGame(gameConfig, pack)
 	if err := ebiten.RunGame(g); err != nil {
 		panic(err)
 	}
@@ -0,0 +1,256 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
+	"github.com/juanancid/maze-adventure/internal/engine/mazeanalysis"
+	"github.com/juanancid/maze-adventure/internal/engine/mazeascii"
+)
+
+// Colors match the in-game maze renderer
+var (
+	backgroundColor = color.RGBA{R: 0x12, G: 0x18, B: 0x21, A: 0xFF}
+	wallColor       = color.RGBA{R: 0x36, G: 0x9b, B: 0x48, A: 0xFF}
+	deadlyColor     = color.RGBA{R: 0xFF, G: 0x00, B: 0x00, A: 0xFF}
+	freezingColor   = color.RGBA{R: 0x00, G: 0xFF, B: 0xFF, A: 0xFF}
+	solutionColor   = color.RGBA{R: 0x5A, G: 0x5F, B: 0x8C, A: 0xFF}
+	startColor      = color.RGBA{R: 0xFF, G: 0xD7, B: 0x00, A: 0xFF}
+	exitColor       = color.RGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF}
+)
+
+// mazeMap is a layout with the cells where the player starts and leaves
+type mazeMap struct {
+	layout mazelayout.Layout
+	start  mazeanalysis.Point
+	exit   mazeanalysis.Point
+}
+
+func (m mazeMap) ascii() ([]byte, error) {
+	text, err := mazeascii.Format(mazeascii.Map{Layout: m.layout, Start: &m.start, Exit: &m.exit})
+	return []byte(text), err
+}
+
+type pointJSON struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type cellJSON struct {
+	Walls [4]bool `json:"walls"` // Top, right, bottom and left
+	Type  string  `json:"type"`  // regular, deadly or freezing
+}
+
+type statsJSON struct {
+	Solvable           bool    `json:"solvable"`
+	SolutionLength     int     `json:"solutionLength"`
+	Tortuosity         float64 `json:"tortuosity"`
+	DecisionPoints     int     `json:"decisionPoints"`
+	DeadEnds           int     `json:"deadEnds"`
+	Corridors          int     `json:"corridors"`
+	Junctions          int     `json:"junctions"`
+	Loops              int     `json:"loops"`
+	Reachable          int     `json:"reachable"`
+	DeadlyOnPath       int     `json:"deadlyOnPath"`
+	FreezingOnPath     int     `json:"freezingOnPath"`
+	UnavoidableHazards int     `json:"unavoidableHazards"`
+	Difficulty         float64 `json:"difficulty"`
+}
+
+type mazeJSON struct {
+	Algorithm             string       `json:"algorithm"`
+	Seed                  int64        `json:"seed"`
+	Cols                  int          `json:"cols"`
+	Rows                  int          `json:"rows"`
+	DeadlyCells           int          `json:"deadlyCells"`
+	FreezingCells         int          `json:"freezingCells"`
+	ExtraConnectionChance float64      `json:"extraConnectionChance"`
+	Start                 pointJSON    `json:"start"`
+	Exit                  pointJSON    `json:"exit"`
+	Solution              []pointJSON  `json:"solution"`
+	Cells                 [][]cellJSON `json:"cells"`
+	Stats                 statsJSON    `json:"stats"`
+}
+
+func (g generatedMaze) json() ([]byte, error) {
+	r := g.report
+	layout := g.maze.layout
+
+	doc := mazeJSON{
+		Algorithm:             string(g.config.Algorithm),
+		Seed:                  g.config.Seed,
+		Cols:                  layout.Cols(),
+		Rows:                  layout.Rows(),
+		DeadlyCells:           g.config.DeadlyCells,
+		FreezingCells:         g.config.FreezingCells,
+		ExtraConnectionChance: g.config.ExtraConnectionChance,
+		Start:                 pointJSON{X: g.maze.start.X, Y: g.maze.start.Y},
+		Exit:                  pointJSON{X: g.maze.exit.X, Y: g.maze.exit.Y},
+		Solution:              make([]pointJSON, 0, len(r.SolutionPath)),
+		Cells:                 make([][]cellJSON, layout.Rows()),
+		Stats: statsJSON{
+			Solvable:           r.Solvable,
+			SolutionLength:     r.SolutionLength,
+			Tortuosity:         r.Tortuosity,
+			DecisionPoints:     r.DecisionPoints,
+			DeadEnds:           r.DeadEnds,
+			Corridors:          r.Corridors,
+			Junctions:          r.Junctions,
+			Loops:              r.Loops,
+			Reachable:          r.Reachable,
+			DeadlyOnPath:       r.DeadlyOnPath,
+			FreezingOnPath:     r.FreezingOnPath,
+			UnavoidableHazards: r.UnavoidableHazards,
+			Difficulty:         r.Difficulty,
+		},
+	}
+
+	for _, p := range r.SolutionPath {
+		doc.Solution = append(doc.Solution, pointJSON{X: p.X, Y: p.Y})
+	}
+
+	for y := range doc.Cells {
+		doc.Cells[y] = make([]cellJSON, layout.Cols())
+		for x := range doc.Cells[y] {
+			cell := layout.GetCell(x, y)
+			cellType := "regular"
+			switch {
+			case cell.IsDeadly():
+				cellType = "deadly"
+			case cell.IsFreezing():
+				cellType = "freezing"
+			}
+			doc.Cells[y][x] = cellJSON{Walls: cell.GetWalls(), Type: cellType}
+		}
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// canvas is what the maze is painted on, every shape is drawn as a rectangle
+type canvas interface {
+	fillRect(x, y, width, height int, c color.RGBA)
+}
+
+// paint draws the maze, its hazards, the solution and the start and exit cells
+func (m mazeMap) paint(dst canvas, cellSize int, solution []mazeanalysis.Point) {
+	cols, rows := m.layout.Cols(), m.layout.Rows()
+	wall := max(cellSize/12, 1)
+	marker := cellSize / 2
+
+	dst.fillRect(0, 0, cols*cellSize+wall, rows*cellSize+wall, backgroundColor)
+
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			cell := m.layout.GetCell(x, y)
+			switch {
+			case cell.IsDeadly():
+				dst.fillRect(x*cellSize+wall, y*cellSize+wall, cellSize-wall, cellSize-wall, dim(deadlyColor))
+			case cell.IsFreezing():
+				dst.fillRect(x*cellSize+wall, y*cellSize+wall, cellSize-wall, cellSize-wall, dim(freezingColor))
+			}
+		}
+	}
+
+	// The solution is drawn as segments joining the centers of consecutive cells
+	center := func(p mazeanalysis.Point) (int, int) {
+		return p.X*cellSize + cellSize/2, p.Y*cellSize + cellSize/2
+	}
+	for i := 1; i < len(solution); i++ {
+		x1, y1 := center(solution[i-1])
+		x2, y2 := center(solution[i])
+		dst.fillRect(min(x1, x2)-wall/2, min(y1, y2)-wall/2, abs(x2-x1)+wall, abs(y2-y1)+wall, solutionColor)
+	}
+
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			cell := m.layout.GetCell(x, y)
+			px, py := x*cellSize, y*cellSize
+			if cell.HasTopWall() {
+				dst.fillRect(px, py, cellSize+wall, wall, wallColor)
+			}
+			if cell.HasLeftWall() {
+				dst.fillRect(px, py, wall, cellSize+wall, wallColor)
+			}
+			if cell.HasBottomWall() {
+				dst.fillRect(px, py+cellSize, cellSize+wall, wall, wallColor)
+			}
+			if cell.HasRightWall() {
+				dst.fillRect(px+cellSize, py, wall, cellSize+wall, wallColor)
+			}
+		}
+	}
+
+	for _, p := range []struct {
+		cell mazeanalysis.Point
+		c    color.RGBA
+	}{{m.start, startColor}, {m.exit, exitColor}} {
+		cx, cy := center(p.cell)
+		dst.fillRect(cx-marker/2, cy-marker/2, marker, marker, p.c)
+	}
+}
+
+// imageCanvas paints on an in-memory image
+type imageCanvas struct {
+	img *image.RGBA
+}
+
+func (c imageCanvas) fillRect(x, y, width, height int, col color.RGBA) {
+	draw.Draw(c.img, image.Rect(x, y, x+width, y+height), image.NewUniform(col), image.Point{}, draw.Src)
+}
+
+func (m mazeMap) png(cellSize int, solution []mazeanalysis.Point) ([]byte, error) {
+	wall := max(cellSize/12, 1)
+	img := image.NewRGBA(image.Rect(0, 0, m.layout.Cols()*cellSize+wall, m.layout.Rows()*cellSize+wall))
+	m.paint(imageCanvas{img: img}, cellSize, solution)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// svgCanvas writes every rectangle as an SVG element
+type svgCanvas struct {
+	buf *bytes.Buffer
+}
+
+func (c svgCanvas) fillRect(x, y, width, height int, col color.RGBA) {
+	fmt.Fprintf(c.buf, "  <rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"#%02x%02x%02x\"/>\n", x, y, width, height, col.R, col.G, col.B)
+}
+
+func (m mazeMap) svg(cellSize int, solution []mazeanalysis.Point) []byte {
+	wall := max(cellSize/12, 1)
+	width := m.layout.Cols()*cellSize + wall
+	height := m.layout.Rows()*cellSize + wall
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\" shape-rendering=\"crispEdges\">\n", width, height, width, height)
+	m.paint(svgCanvas{buf: &buf}, cellSize, solution)
+	buf.WriteString("</svg>\n")
+
+	return buf.Bytes()
+}
+
+// dim darkens a color so walls and markers stand out over the cell it fills
+func dim(c color.RGBA) color.RGBA {
+	return color.RGBA{R: c.R / 3, G: c.G / 3, B: c.B / 3, A: c.A}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
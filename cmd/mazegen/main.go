@@ -0,0 +1,207 @@
+// Maze Generator
+//
+// Generates mazes offline, without launching the game, and writes them to disk
+// along with their solver statistics so they can be reviewed in batches.
+//
+// Command-line options:
+//
+//	--algorithm NAME    Maze generation algorithm (default: recursive-backtracker)
+//	--cols N            Number of columns (default: 16)
+//	--rows N            Number of rows (default: 9)
+//	--seed N            Seed of the first maze, the next ones use N+1, N+2... (0 = random)
+//	--deadly N          Number of deadly cells to place
+//	--freezing N        Number of freezing cells to place
+//	--extra P           Probability (0.0-1.0) of adding extra connections between cells
+//	--count N           Number of mazes to generate (default: 1)
+//	--out DIR           Output directory (default: current directory)
+//	--formats LIST      Comma-separated output formats: ascii, json, png, svg (default: all)
+//	--cell N            Cell size in pixels for the png and svg outputs (default: 24)
+//
+// A line of statistics is printed for every maze as tab-separated values.
+//
+// Examples:
+//
+//	go run ./cmd/mazegen --algorithm prim --seed 42
+//	go run ./cmd/mazegen --count 200 --deadly 3 --formats ascii,png --out candidates
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juanancid/maze-adventure/internal/engine/mazeanalysis"
+	"github.com/juanancid/maze-adventure/internal/engine/mazebuilder"
+)
+
+// Output formats
+const (
+	formatASCII = "ascii"
+	formatJSON  = "json"
+	formatPNG   = "png"
+	formatSVG   = "svg"
+)
+
+var extensions = map[string]string{
+	formatASCII: ".txt",
+	formatJSON:  ".json",
+	formatPNG:   ".png",
+	formatSVG:   ".svg",
+}
+
+func main() {
+	algorithm := flag.String("algorithm", string(mazebuilder.DefaultAlgorithm), "Maze generation algorithm: "+algorithmNames())
+	cols := flag.Int("cols", 16, "Number of columns")
+	rows := flag.Int("rows", 9, "Number of rows")
+	seed := flag.Int64("seed", 0, "Seed of the first maze (0 = random)")
+	deadly := flag.Int("deadly", 0, "Number of deadly cells")
+	freezing := flag.Int("freezing", 0, "Number of freezing cells")
+	extra := flag.Float64("extra", 0, "Probability (0.0-1.0) of adding extra connections")
+	count := flag.Int("count", 1, "Number of mazes to generate")
+	out := flag.String("out", ".", "Output directory")
+	formats := flag.String("formats", "ascii,json,png,svg", "Comma-separated output formats: ascii, json, png, svg")
+	cellSize := flag.Int("cell", 24, "Cell size in pixels for the png and svg outputs")
+	flag.Parse()
+
+	selectedFormats, err := parseFormats(*formats)
+	if err != nil {
+		exitWithError(err)
+	}
+	if *count < 1 {
+		exitWithError(fmt.Errorf("count must be at least 1, got: %d", *count))
+	}
+	if *cellSize < 4 {
+		exitWithError(fmt.Errorf("cell size must be at least 4 pixels, got: %d", *cellSize))
+	}
+	if !mazebuilder.Algorithm(*algorithm).IsValid() {
+		exitWithError(fmt.Errorf("unknown maze generation algorithm %q, expected one of: %s", *algorithm, algorithmNames()))
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		exitWithError(fmt.Errorf("failed to create output directory: %w", err))
+	}
+
+	fmt.Println(statsHeader())
+	for i := 0; i < *count; i++ {
+		config := mazebuilder.NewBuilderConfig(*cols, *rows)
+		config.Algorithm = mazebuilder.Algorithm(*algorithm)
+		config.Seed = *seed + int64(i)
+		config.DeadlyCells = *deadly
+		config.FreezingCells = *freezing
+		config.ExtraConnectionChance = *extra
+
+		m, err := generate(config)
+		if err != nil {
+			exitWithError(fmt.Errorf("seed %d: %w", config.Seed, err))
+		}
+
+		name := fmt.Sprintf("maze-%s-%d", config.Algorithm, config.Seed)
+		for _, format := range selectedFormats {
+			path := filepath.Join(*out, name+extensions[format])
+			if err := m.write(path, format, *cellSize); err != nil {
+				exitWithError(fmt.Errorf("failed to write %s: %w", path, err))
+			}
+		}
+
+		fmt.Println(m.statsLine())
+	}
+}
+
+// generatedMaze is a built layout along with the settings that produced it
+type generatedMaze struct {
+	config *mazebuilder.BuilderConfig
+	report mazeanalysis.Report
+	maze   mazeMap
+}
+
+func generate(config *mazebuilder.BuilderConfig) (generatedMaze, error) {
+	layout, err := mazebuilder.Build(config)
+	if err != nil {
+		return generatedMaze{}, err
+	}
+
+	return generatedMaze{
+		config: config,
+		report: mazeanalysis.Analyze(layout, config.Start, config.Exit),
+		maze: mazeMap{
+			layout: layout,
+			start:  config.Start,
+			exit:   config.Exit,
+		},
+	}, nil
+}
+
+func (g generatedMaze) write(path, format string, cellSize int) error {
+	var data []byte
+	var err error
+
+	switch format {
+	case formatASCII:
+		data, err = g.maze.ascii()
+	case formatJSON:
+		data, err = g.json()
+	case formatPNG:
+		data, err = g.maze.png(cellSize, g.report.SolutionPath)
+	case formatSVG:
+		data = g.maze.svg(cellSize, g.report.SolutionPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+func statsHeader() string {
+	return strings.Join([]string{
+		"algorithm", "seed", "cols", "rows", "solvable", "solution", "tortuosity", "decisions",
+		"dead_ends", "junctions", "corridors", "loops", "hazards_on_path", "unavoidable_hazards", "difficulty",
+	}, "\t")
+}
+
+func (g generatedMaze) statsLine() string {
+	r := g.report
+	return fmt.Sprintf("%s\t%d\t%d\t%d\t%t\t%d\t%.2f\t%d\t%d\t%d\t%d\t%d\t%d\t%d\t%.1f",
+		g.config.Algorithm, g.config.Seed, r.Cols, r.Rows, r.Solvable, r.SolutionLength, r.Tortuosity, r.DecisionPoints,
+		r.DeadEnds, r.Junctions, r.Corridors, r.Loops, r.HazardsOnPath, r.UnavoidableHazards, r.Difficulty)
+}
+
+func parseFormats(list string) ([]string, error) {
+	var formats []string
+	for _, format := range strings.Split(list, ",") {
+		format = strings.ToLower(strings.TrimSpace(format))
+		if format == "" {
+			continue
+		}
+		if _, ok := extensions[format]; !ok {
+			return nil, fmt.Errorf("unknown output format %q, expected ascii, json, png or svg", format)
+		}
+		formats = append(formats, format)
+	}
+
+	if len(formats) == 0 {
+		return nil, fmt.Errorf("at least one output format is required")
+	}
+
+	return formats, nil
+}
+
+func algorithmNames() string {
+	names := make([]string, 0, len(mazebuilder.Algorithms()))
+	for _, algorithm := range mazebuilder.Algorithms() {
+		names = append(names, string(algorithm))
+	}
+	return strings.Join(names, ", ")
+}
+
+func exitWithError(err error) {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	os.Exit(1)
+}
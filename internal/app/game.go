@@ -1,6 +1,8 @@
 package app
 
 import (
+	"log"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
 
@@ -9,6 +11,7 @@ import (
 	"github.com/juanancid/maze-adventure/internal/engine/input"
 	gameplayconfig "github.com/juanancid/maze-adventure/internal/gameplay/config"
 	"github.com/juanancid/maze-adventure/internal/gameplay/levels"
+	"github.com/juanancid/maze-adventure/internal/gameplay/save"
 	"github.com/juanancid/maze-adventure/internal/gameplay/states"
 )
 
@@ -18,23 +21,19 @@ type Game struct {
 	config       gameplayconfig.GameConfig
 }
 
-func NewGame(config gameplayconfig.GameConfig) *Game {
-	var levelManager *levels.Manager
-	var err error
+func NewGame(config gameplayconfig.GameConfig, pack *levels.Pack) *Game {
+	if pack == nil {
+		pack = levels.DefaultPack()
+	}
 
-	// Create level manager with appropriate starting level
-	if config.StartingLevel > 1 {
-		levelManager, err = levels.NewManagerWithStartingLevel(config.StartingLevel)
-		if err != nil {
-			// Fallback to default manager if there's an error
-			levelManager = levels.NewManager()
-		}
-	} else {
-		levelManager = levels.NewManager()
+	// Without a place to keep saves the game is still playable, progress is just not saved
+	saves, err := save.NewDefaultStore()
+	if err != nil {
+		log.Printf("Saving is disabled: %v", err)
 	}
 
 	stateManager := states.NewManager(nil)
-	bootState := states.NewBootState(stateManager, levelManager, config)
+	bootState := states.NewBootState(stateManager, pack, config, saves)
 	stateManager.ChangeState(bootState)
 
 	inputHandler := input.NewHandler()
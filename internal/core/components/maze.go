@@ -1,7 +1,9 @@
 package components
 
+import "github.com/juanancid/maze-adventure/internal/core/mazelayout"
+
 type Maze struct {
-	Layout     Layout
+	Layout     mazelayout.Layout
 	CellWidth  int
 	CellHeight int
 }
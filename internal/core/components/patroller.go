@@ -13,10 +13,11 @@ const (
 // PatrollerState tracks the current movement state of a patroller
 type PatrollerState struct {
 	CurrentDirection    int     // Current movement direction (0=up, 1=right, 2=down, 3=left)
-	LastDirectionChange float64 // Time of last direction change
+	LastDirectionChange float64 // Simulation time of last direction change, in seconds
 	MovementPhase       int     // Current phase for pattern-specific behavior
 	SpawnCol            int     // Original spawn column
 	SpawnRow            int     // Original spawn row
+	RandomState         uint64  // State of the patroller's private random sequence
 }
 
 // Patroller represents an NPC that patrols the maze
@@ -56,6 +57,28 @@ func NewPatrollerWithPattern(id int, pattern PatrolPattern, spawnCol, spawnRow i
 	return patroller
 }
 
+// Seed sets the starting point of the patroller's private random sequence.
+// Keeping the sequence inside the component makes patroller decisions reproducible from the level seed.
+func (p *Patroller) Seed(seed uint64) {
+	p.State.RandomState = seed
+}
+
+// NextRandom advances the patroller's random sequence and returns a value in [0.0, 1.0)
+func (p *Patroller) NextRandom() float64 {
+	// splitmix64 step
+	p.State.RandomState += 0x9E3779B97F4A7C15
+	z := p.State.RandomState
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	z ^= z >> 31
+	return float64(z>>11) / (1 << 53)
+}
+
+// NextRandomIntn advances the patroller's random sequence and returns a value in [0, n)
+func (p *Patroller) NextRandomIntn(n int) int {
+	return int(p.NextRandom() * float64(n))
+}
+
 // GetDamage returns the damage this patroller deals
 func (p *Patroller) GetDamage() int {
 	return p.Damage
@@ -0,0 +1,55 @@
+package entities
+
+// CommandBuffer queues changes to the world so systems can request them while iterating
+// over queries. The changes are applied in order when the world is flushed.
+type CommandBuffer struct {
+	world    *World
+	commands []func(*World)
+}
+
+// Create returns a new entity right away so components can be queued for it.
+// The entity has no components, and so shows up in no query, until the next flush.
+func (b *CommandBuffer) Create() Entity {
+	return b.world.NewEntity()
+}
+
+// Destroy queues the removal of the entity and all its components
+func (b *CommandBuffer) Destroy(entity Entity) {
+	b.push(func(w *World) {
+		w.RemoveEntity(entity)
+	})
+}
+
+// Len returns the number of queued commands
+func (b *CommandBuffer) Len() int {
+	return len(b.commands)
+}
+
+func (b *CommandBuffer) push(command func(*World)) {
+	b.commands = append(b.commands, command)
+}
+
+// apply runs the queued commands, including the ones queued while applying them
+func (b *CommandBuffer) apply() {
+	for len(b.commands) > 0 {
+		commands := b.commands
+		b.commands = nil
+		for _, command := range commands {
+			command(b.world)
+		}
+	}
+}
+
+// QueueAdd queues setting the T component of the entity
+func QueueAdd[T any](b *CommandBuffer, entity Entity, component *T) {
+	b.push(func(w *World) {
+		Add(w, entity, component)
+	})
+}
+
+// QueueRemove queues deleting the T component of the entity
+func QueueRemove[T any](b *CommandBuffer, entity Entity) {
+	b.push(func(w *World) {
+		Remove[T](w, entity)
+	})
+}
@@ -1,8 +1,6 @@
 package entities
 
 import (
-	"reflect"
-
 	"github.com/juanancid/maze-adventure/internal/core/components"
 )
 
@@ -10,13 +8,16 @@ import (
 type EntityList []Entity
 
 func (entities EntityList) GetPosition(w *World, entity Entity) *components.Position {
-	return w.GetComponent(entity, reflect.TypeOf(&components.Position{})).(*components.Position)
+	position, _ := Get[components.Position](w, entity)
+	return position
 }
 
 func (entities EntityList) GetSize(w *World, entity Entity) *components.Size {
-	return w.GetComponent(entity, reflect.TypeOf(&components.Size{})).(*components.Size)
+	size, _ := Get[components.Size](w, entity)
+	return size
 }
 
 func (entities EntityList) GetVelocity(w *World, entity Entity) *components.Velocity {
-	return w.GetComponent(entity, reflect.TypeOf(&components.Velocity{})).(*components.Velocity)
+	velocity, _ := Get[components.Velocity](w, entity)
+	return velocity
 }
@@ -0,0 +1,303 @@
+package entities
+
+import (
+	"iter"
+	"reflect"
+	"slices"
+)
+
+// sparseSet keeps values packed in a dense array sorted by entity, with an index per entity for O(1) lookups
+type sparseSet[V any] struct {
+	sparse   []int // Entity index -> dense index + 1, 0 means the entity has no value
+	entities []Entity
+	values   []V
+}
+
+// index returns the dense position of the entity. Stale handles of a reused index are not found.
+func (s *sparseSet[V]) index(entity Entity) (int, bool) {
+	slot := entity.Index()
+	if slot >= len(s.sparse) || s.sparse[slot] == 0 {
+		return 0, false
+	}
+
+	i := s.sparse[slot] - 1
+	if s.entities[i] != entity {
+		return 0, false
+	}
+	return i, true
+}
+
+func (s *sparseSet[V]) get(entity Entity) (V, bool) {
+	i, ok := s.index(entity)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return s.values[i], true
+}
+
+func (s *sparseSet[V]) has(entity Entity) bool {
+	_, ok := s.index(entity)
+	return ok
+}
+
+func (s *sparseSet[V]) set(entity Entity, value V) {
+	if i, ok := s.index(entity); ok {
+		s.values[i] = value
+		return
+	}
+
+	if slot := entity.Index(); slot >= len(s.sparse) {
+		s.sparse = append(s.sparse, make([]int, slot+1-len(s.sparse))...)
+	} else if s.sparse[slot] != 0 {
+		// A stale handle of the same index still has a value, it is replaced by the new one
+		s.remove(s.entities[s.sparse[slot]-1])
+	}
+
+	// Entities are kept sorted so iteration order does not depend on the order of insertions and removals.
+	// New entities usually sort last, so this is mostly a plain append.
+	i, _ := slices.BinarySearch(s.entities, entity)
+	s.entities = slices.Insert(s.entities, i, entity)
+	s.values = slices.Insert(s.values, i, value)
+	s.reindex(i)
+}
+
+// remove shifts the following values back to keep the dense array packed and sorted
+func (s *sparseSet[V]) remove(entity Entity) {
+	i, ok := s.index(entity)
+	if !ok {
+		return
+	}
+
+	s.entities = slices.Delete(s.entities, i, i+1)
+	s.values = slices.Delete(s.values, i, i+1)
+	s.sparse[entity.Index()] = 0
+	s.reindex(i)
+}
+
+// reindex updates the sparse index of the entities from the given dense position onwards
+func (s *sparseSet[V]) reindex(from int) {
+	for i := from; i < len(s.entities); i++ {
+		s.sparse[s.entities[i].Index()] = i + 1
+	}
+}
+
+func (s *sparseSet[V]) len() int {
+	return len(s.values)
+}
+
+// componentStore is the type-erased view of a store used by the reflection-based API
+type componentStore interface {
+	component(entity Entity) (Component, bool)
+	setComponent(entity Entity, component Component)
+	has(entity Entity) bool
+	remove(entity Entity)
+	entityList() []Entity
+	len() int
+}
+
+// Store holds every component of type T, stored as *T like the reflection-based API does
+type Store[T any] struct {
+	set sparseSet[*T]
+}
+
+// Get returns the component of the entity
+func (s *Store[T]) Get(entity Entity) (*T, bool) {
+	return s.set.get(entity)
+}
+
+// Has returns true if the entity has a component in the store
+func (s *Store[T]) Has(entity Entity) bool {
+	return s.set.has(entity)
+}
+
+// Add sets the component of the entity, replacing the previous one if any
+func (s *Store[T]) Add(entity Entity, component *T) {
+	s.set.set(entity, component)
+}
+
+// Remove deletes the component of the entity, if any
+func (s *Store[T]) Remove(entity Entity) {
+	s.set.remove(entity)
+}
+
+// Len returns the number of components in the store
+func (s *Store[T]) Len() int {
+	return s.set.len()
+}
+
+// All iterates over every entity of the store along with its component, sorted by entity.
+// Components must not be added to or removed from the store while iterating, queue the
+// changes in the world command buffer instead.
+func (s *Store[T]) All() iter.Seq2[Entity, *T] {
+	return func(yield func(Entity, *T) bool) {
+		for i, entity := range s.set.entities {
+			if !yield(entity, s.set.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+func (s *Store[T]) component(entity Entity) (Component, bool) {
+	return s.set.get(entity)
+}
+
+func (s *Store[T]) setComponent(entity Entity, component Component) {
+	s.set.set(entity, component.(*T))
+}
+
+func (s *Store[T]) has(entity Entity) bool {
+	return s.set.has(entity)
+}
+
+func (s *Store[T]) remove(entity Entity) {
+	s.set.remove(entity)
+}
+
+func (s *Store[T]) entityList() []Entity {
+	return s.set.entities
+}
+
+func (s *Store[T]) len() int {
+	return s.set.len()
+}
+
+// erasedStore holds components added through the reflection-based API before any typed
+// access. It is migrated to a Store[T] the first time its type is used through the generic API.
+type erasedStore struct {
+	set sparseSet[Component]
+}
+
+func (s *erasedStore) component(entity Entity) (Component, bool) {
+	return s.set.get(entity)
+}
+
+func (s *erasedStore) setComponent(entity Entity, component Component) {
+	s.set.set(entity, component)
+}
+
+func (s *erasedStore) has(entity Entity) bool {
+	return s.set.has(entity)
+}
+
+func (s *erasedStore) remove(entity Entity) {
+	s.set.remove(entity)
+}
+
+func (s *erasedStore) entityList() []Entity {
+	return s.set.entities
+}
+
+func (s *erasedStore) len() int {
+	return s.set.len()
+}
+
+// StoreOf returns the store of T components, creating it if needed
+func StoreOf[T any](w *World) *Store[T] {
+	componentType := reflect.TypeFor[*T]()
+
+	switch store := w.stores[componentType].(type) {
+	case *Store[T]:
+		return store
+	case *erasedStore:
+		typed := &Store[T]{}
+		for i, entity := range store.set.entities {
+			typed.Add(entity, store.set.values[i].(*T))
+		}
+		w.stores[componentType] = typed
+		return typed
+	default:
+		typed := &Store[T]{}
+		w.stores[componentType] = typed
+		return typed
+	}
+}
+
+// Get returns the T component of the entity
+func Get[T any](w *World, entity Entity) (*T, bool) {
+	return StoreOf[T](w).Get(entity)
+}
+
+// Has returns true if the entity has a T component
+func Has[T any](w *World, entity Entity) bool {
+	return StoreOf[T](w).Has(entity)
+}
+
+// Add sets the T component of the entity, replacing the previous one if any.
+// Components of entities that are not alive are ignored.
+func Add[T any](w *World, entity Entity, component *T) {
+	if !w.IsAlive(entity) {
+		return
+	}
+	StoreOf[T](w).Add(entity, component)
+}
+
+// Remove deletes the T component of the entity, if any
+func Remove[T any](w *World, entity Entity) {
+	StoreOf[T](w).Remove(entity)
+}
+
+// All iterates over the entities that have a T component
+func All[T any](w *World) iter.Seq2[Entity, *T] {
+	return StoreOf[T](w).All()
+}
+
+// Row2 holds the components of an entity matched by Query2
+type Row2[A, B any] struct {
+	A *A
+	B *B
+}
+
+// Row3 holds the components of an entity matched by Query3
+type Row3[A, B, C any] struct {
+	A *A
+	B *B
+	C *C
+}
+
+// Query2 iterates over the entities that have both an A and a B component, sorted by entity.
+// Components of these types must not be added or removed while iterating, queue the
+// changes in the world command buffer instead.
+func Query2[A, B any](w *World) iter.Seq2[Entity, Row2[A, B]] {
+	storeA, storeB := StoreOf[A](w), StoreOf[B](w)
+
+	return func(yield func(Entity, Row2[A, B]) bool) {
+		for _, entity := range smallest(storeA, storeB).entityList() {
+			a, okA := storeA.Get(entity)
+			b, okB := storeB.Get(entity)
+			if okA && okB && !yield(entity, Row2[A, B]{A: a, B: b}) {
+				return
+			}
+		}
+	}
+}
+
+// Query3 iterates over the entities that have an A, a B and a C component, sorted by entity.
+// Components of these types must not be added or removed while iterating, queue the
+// changes in the world command buffer instead.
+func Query3[A, B, C any](w *World) iter.Seq2[Entity, Row3[A, B, C]] {
+	storeA, storeB, storeC := StoreOf[A](w), StoreOf[B](w), StoreOf[C](w)
+
+	return func(yield func(Entity, Row3[A, B, C]) bool) {
+		for _, entity := range smallest(storeA, storeB, storeC).entityList() {
+			a, okA := storeA.Get(entity)
+			b, okB := storeB.Get(entity)
+			c, okC := storeC.Get(entity)
+			if okA && okB && okC && !yield(entity, Row3[A, B, C]{A: a, B: b, C: c}) {
+				return
+			}
+		}
+	}
+}
+
+// smallest returns the store with the fewest components, the cheapest one to drive a query
+func smallest(stores ...componentStore) componentStore {
+	result := stores[0]
+	for _, store := range stores[1:] {
+		if store.len() < result.len() {
+			result = store
+		}
+	}
+	return result
+}
@@ -1,86 +1,242 @@
 package entities
 
 import (
+	"fmt"
 	"reflect"
+	"slices"
 )
 
+// World holds every entity and its components. Components are kept in dense per-type
+// stores, accessed through the generic functions (Get, Add, Query2...) or through the
+// reflection-based methods below, which are kept for compatibility.
 type World struct {
-	nextEntityID Entity
-	components   map[reflect.Type]map[Entity]Component
+	generations []uint32 // Current generation of every entity index
+	alive       []bool   // Whether the entity index is in use
+	free        []uint32 // Indexes of removed entities, reused by NewEntity
+	stores      map[reflect.Type]componentStore
+	commands    CommandBuffer
 }
 
-type Entity int
+// Entity is a handle made of an index and a generation. Indexes are reused once their
+// entity is removed, and the generation tells stale handles from the entity reusing it.
+type Entity uint64
 
 type Component interface{}
 
+const indexBits = 32
+
+// Index returns the slot of the entity, shared with the entities that reuse it
+func (e Entity) Index() int {
+	return int(uint32(e))
+}
+
+// Generation returns how many times the slot of the entity was reused before it
+func (e Entity) Generation() uint32 {
+	return uint32(e >> indexBits)
+}
+
+func newEntity(index, generation uint32) Entity {
+	return Entity(generation)<<indexBits | Entity(index)
+}
+
 func NewWorld() *World {
-	return &World{
-		nextEntityID: 0,
-		components:   make(map[reflect.Type]map[Entity]Component),
+	w := &World{
+		stores: make(map[reflect.Type]componentStore),
+	}
+	w.commands.world = w
+	return w
+}
+
+// Allocation is the state of the entity allocator. Saved along with the components,
+// it restores a world where every handle still refers to the same entity.
+type Allocation struct {
+	Generations []uint32 `json:"generations"`
+	Alive       []bool   `json:"alive"`
+	Free        []uint32 `json:"free"` // Reused last first
+}
+
+// Allocation returns a copy of the state of the entity allocator
+func (w *World) Allocation() Allocation {
+	return Allocation{
+		Generations: slices.Clone(w.generations),
+		Alive:       slices.Clone(w.alive),
+		Free:        slices.Clone(w.free),
+	}
+}
+
+// NewWorldFromAllocation creates a world without components whose entities are allocated
+// as described, so that the components of a saved world can be added back to the same handles
+func NewWorldFromAllocation(allocation Allocation) (*World, error) {
+	if len(allocation.Alive) != len(allocation.Generations) {
+		return nil, fmt.Errorf("allocation has %d generations but %d alive flags", len(allocation.Generations), len(allocation.Alive))
+	}
+
+	freed := make([]bool, len(allocation.Generations))
+	for _, index := range allocation.Free {
+		if int(index) >= len(allocation.Generations) {
+			return nil, fmt.Errorf("free entity index %d out of range", index)
+		}
+		if allocation.Alive[index] || freed[index] {
+			return nil, fmt.Errorf("entity index %d cannot be free", index)
+		}
+		freed[index] = true
+	}
+	for index, alive := range allocation.Alive {
+		if !alive && !freed[index] {
+			return nil, fmt.Errorf("entity index %d is neither alive nor free", index)
+		}
+	}
+
+	w := NewWorld()
+	w.generations = slices.Clone(allocation.Generations)
+	w.alive = slices.Clone(allocation.Alive)
+	w.free = slices.Clone(allocation.Free)
+	return w, nil
+}
+
+// ComponentTypes returns the types of the components held by the world
+func (w *World) ComponentTypes() []reflect.Type {
+	var types []reflect.Type
+	for componentType, store := range w.stores {
+		if store.len() > 0 {
+			types = append(types, componentType)
+		}
 	}
+	return types
 }
 
+// NewEntity returns a new entity, reusing the index of a removed one if any
 func (w *World) NewEntity() Entity {
-	id := w.nextEntityID
-	w.nextEntityID++
-	return id
+	if last := len(w.free) - 1; last >= 0 {
+		index := w.free[last]
+		w.free = w.free[:last]
+		w.alive[index] = true
+		return newEntity(index, w.generations[index])
+	}
+
+	index := uint32(len(w.generations))
+	w.generations = append(w.generations, 0)
+	w.alive = append(w.alive, true)
+	return newEntity(index, 0)
+}
+
+// IsAlive returns false if the entity was removed, even if its index was reused since then
+func (w *World) IsAlive(entity Entity) bool {
+	index := entity.Index()
+	return index < len(w.generations) && w.alive[index] && w.generations[index] == entity.Generation()
+}
+
+// Commands returns the buffer where changes are queued until the next Flush
+func (w *World) Commands() *CommandBuffer {
+	return &w.commands
 }
 
+// Flush applies the queued commands in the order they were queued.
+// It must be called when no system is iterating over the world.
+func (w *World) Flush() {
+	w.commands.apply()
+}
+
+// AddComponent sets a component of the entity, keyed by the component's dynamic type.
+// Components of entities that are not alive are ignored.
+//
+// Deprecated: use Add.
 func (w *World) AddComponent(entity Entity, component Component) {
+	if !w.IsAlive(entity) {
+		return
+	}
+
 	componentType := reflect.TypeOf(component)
-	if w.components[componentType] == nil {
-		w.components[componentType] = make(map[Entity]Component)
+	store, exists := w.stores[componentType]
+	if !exists {
+		store = &erasedStore{}
+		w.stores[componentType] = store
 	}
-	w.components[componentType][entity] = component
+	store.setComponent(entity, component)
 }
 
+// GetComponent returns the component of the given type of the entity, or nil.
+//
+// Deprecated: use Get.
 func (w *World) GetComponent(entity Entity, componentType reflect.Type) Component {
-	if componentMap, exists := w.components[componentType]; exists {
-		return componentMap[entity]
+	if store, exists := w.stores[componentType]; exists {
+		if component, found := store.component(entity); found {
+			return component
+		}
 	}
 	return nil
 }
 
 // HasComponent checks if an entity has a specific component
+//
+// Deprecated: use Has.
 func (w *World) HasComponent(entity Entity, componentType reflect.Type) bool {
-	if componentMap, exists := w.components[componentType]; exists {
-		_, hasComponent := componentMap[entity]
-		return hasComponent
+	if store, exists := w.stores[componentType]; exists {
+		return store.has(entity)
 	}
 	return false
 }
 
-func (w *World) GetComponents(componentType reflect.Type) map[Entity]Component {
-	return w.components[componentType]
+// ComponentEntry is a component along with the entity it belongs to
+type ComponentEntry struct {
+	Entity    Entity
+	Component Component
+}
+
+// GetComponents returns every component of the given type, sorted by entity.
+//
+// Deprecated: use All.
+func (w *World) GetComponents(componentType reflect.Type) []ComponentEntry {
+	store, exists := w.stores[componentType]
+	if !exists {
+		return nil
+	}
+
+	result := make([]ComponentEntry, 0, store.len())
+	for _, entity := range store.entityList() {
+		component, _ := store.component(entity)
+		result = append(result, ComponentEntry{Entity: entity, Component: component})
+	}
+	return result
 }
 
+// Query returns the entities that have a component of every given type, sorted by entity.
+//
+// Deprecated: use Query2, Query3 or All.
 func (w *World) Query(types ...reflect.Type) EntityList {
 	if len(types) == 0 {
 		return nil
 	}
 
-	matching := make(map[Entity]bool)
-	firstType := types[0]
-	for e := range w.components[firstType] {
-		matching[e] = true
+	stores := make([]componentStore, 0, len(types))
+	for _, t := range types {
+		store, exists := w.stores[t]
+		if !exists {
+			return EntityList{}
+		}
+		stores = append(stores, store)
 	}
 
-	for _, t := range types[1:] {
-		for e := range matching {
-			if _, exists := w.components[t][e]; !exists {
-				delete(matching, e)
+	result := make([]Entity, 0, smallest(stores...).len())
+	for _, e := range smallest(stores...).entityList() {
+		matches := true
+		for _, store := range stores {
+			if !store.has(e) {
+				matches = false
+				break
 			}
 		}
-	}
-
-	result := make([]Entity, 0, len(matching))
-	for e := range matching {
-		result = append(result, e)
+		if matches {
+			result = append(result, e)
+		}
 	}
 
 	return result
 }
 
+// QueryComponents returns the entities that have a component of the type of every given one, sorted by entity.
+//
+// Deprecated: use Query2, Query3 or All.
 func (w *World) QueryComponents(components ...Component) EntityList {
 	var types []reflect.Type
 	for _, c := range components {
@@ -89,8 +245,19 @@ func (w *World) QueryComponents(components ...Component) EntityList {
 	return w.Query(types...)
 }
 
+// RemoveEntity deletes every component of the entity and frees its index for reuse.
+// Removing an entity that is not alive does nothing.
 func (w *World) RemoveEntity(entity Entity) {
-	for _, componentMap := range w.components {
-		delete(componentMap, entity)
+	if !w.IsAlive(entity) {
+		return
 	}
+
+	for _, store := range w.stores {
+		store.remove(entity)
+	}
+
+	index := entity.Index()
+	w.alive[index] = false
+	w.generations[index]++
+	w.free = append(w.free, uint32(index))
 }
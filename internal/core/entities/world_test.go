@@ -0,0 +1,494 @@
+package entities
+
+import (
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type testPosition struct{ X, Y float64 }
+
+type testVelocity struct{ DX, DY float64 }
+
+type testTag struct{}
+
+var (
+	positionType = reflect.TypeOf(&testPosition{})
+	velocityType = reflect.TypeOf(&testVelocity{})
+)
+
+// populate creates count entities, every one with a position, every other one with a
+// velocity and every third one with a tag. Components are added in an order shuffled
+// with the seed, the order iteration must not depend on.
+func populate(w *World, count int, seed int64) []Entity {
+	entities := make([]Entity, count)
+	for i := range entities {
+		entities[i] = w.NewEntity()
+	}
+
+	shuffled := slices.Clone(entities)
+	rand.New(rand.NewSource(seed)).Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	for _, entity := range shuffled {
+		i := entity.Index()
+		w.AddComponent(entity, &testPosition{X: float64(i)})
+		if i%2 == 0 {
+			Add(w, entity, &testVelocity{DX: 1})
+		}
+		if i%3 == 0 {
+			Add(w, entity, &testTag{})
+		}
+	}
+	return entities
+}
+
+// iterationOrders returns the entities in the order every query API visits them
+func iterationOrders(w *World) map[string][]Entity {
+	orders := make(map[string][]Entity)
+	orders["Query"] = w.Query(positionType, velocityType)
+	for _, entry := range w.GetComponents(positionType) {
+		orders["GetComponents"] = append(orders["GetComponents"], entry.Entity)
+	}
+	for entity := range All[testPosition](w) {
+		orders["All"] = append(orders["All"], entity)
+	}
+	for entity := range Query2[testPosition, testVelocity](w) {
+		orders["Query2"] = append(orders["Query2"], entity)
+	}
+	for entity := range Query3[testPosition, testVelocity, testTag](w) {
+		orders["Query3"] = append(orders["Query3"], entity)
+	}
+	return orders
+}
+
+func TestIterationOrderIsStable(t *testing.T) {
+	var first map[string][]Entity
+	for seed := int64(1); seed <= 5; seed++ {
+		w := NewWorld()
+		entities := populate(w, 200, seed)
+
+		// Removing entities and reusing their indexes must keep the order too
+		for _, entity := range entities[50:60] {
+			w.RemoveEntity(entity)
+		}
+		for range 5 {
+			entity := w.NewEntity()
+			w.AddComponent(entity, &testPosition{})
+			Add(w, entity, &testVelocity{})
+		}
+
+		orders := iterationOrders(w)
+		for api, order := range orders {
+			if !slices.IsSorted(order) {
+				t.Errorf("seed %d: %s does not iterate sorted by entity", seed, api)
+			}
+		}
+
+		if first == nil {
+			first = orders
+			continue
+		}
+		if !reflect.DeepEqual(orders, first) {
+			t.Errorf("seed %d: iteration order differs from the first run", seed)
+		}
+	}
+}
+
+func TestStoreRemoveKeepsOrder(t *testing.T) {
+	w := NewWorld()
+	entities := populate(w, 20, 1)
+	store := StoreOf[testPosition](w)
+	for _, i := range []int{7, 0, 19, 8} {
+		store.Remove(entities[i])
+	}
+
+	var got []Entity
+	for entity, position := range store.All() {
+		if position.X != float64(entity.Index()) {
+			t.Errorf("entity %d has the position of %v", entity, position.X)
+		}
+		got = append(got, entity)
+	}
+
+	want := slices.DeleteFunc(slices.Clone(entities), func(e Entity) bool {
+		return slices.Contains([]int{7, 0, 19, 8}, e.Index())
+	})
+	if !slices.Equal(got, want) {
+		t.Errorf("All = %v, want %v", got, want)
+	}
+}
+
+func BenchmarkQuery(b *testing.B) {
+	w := NewWorld()
+	populate(w, 1000, 1)
+
+	b.ResetTimer()
+	for range b.N {
+		w.Query(positionType, velocityType)
+	}
+}
+
+func BenchmarkQuery2(b *testing.B) {
+	w := NewWorld()
+	populate(w, 1000, 1)
+
+	b.ResetTimer()
+	for range b.N {
+		for range Query2[testPosition, testVelocity](w) {
+		}
+	}
+}
+
+func BenchmarkGetComponents(b *testing.B) {
+	w := NewWorld()
+	populate(w, 1000, 1)
+
+	b.ResetTimer()
+	for range b.N {
+		w.GetComponents(positionType)
+	}
+}
+
+func BenchmarkStoreAdd(b *testing.B) {
+	w := NewWorld()
+	entities := make([]Entity, 1000)
+	for i := range entities {
+		entities[i] = w.NewEntity()
+	}
+	component := &testPosition{}
+
+	b.ResetTimer()
+	for range b.N {
+		store := &Store[testPosition]{}
+		for _, entity := range entities {
+			store.Add(entity, component)
+		}
+	}
+}
+
+func BenchmarkStoreRemove(b *testing.B) {
+	w := NewWorld()
+	entities := make([]Entity, 1000)
+	for i := range entities {
+		entities[i] = w.NewEntity()
+	}
+	component := &testPosition{}
+
+	for range b.N {
+		b.StopTimer()
+		store := &Store[testPosition]{}
+		for _, entity := range entities {
+			store.Add(entity, component)
+		}
+		b.StartTimer()
+
+		// Removing from the front shifts the rest of the store every time, the worst case
+		for _, entity := range entities {
+			store.Remove(entity)
+		}
+	}
+}
+
+func TestEntityHandleKeepsIndexAndGeneration(t *testing.T) {
+	for _, tt := range []struct{ index, generation uint32 }{
+		{0, 0}, {7, 1}, {1<<32 - 1, 3}, {12, 1<<32 - 1},
+	} {
+		entity := newEntity(tt.index, tt.generation)
+		if entity.Index() != int(tt.index) || entity.Generation() != tt.generation {
+			t.Errorf("newEntity(%d, %d) = index %d, generation %d", tt.index, tt.generation, entity.Index(), entity.Generation())
+		}
+	}
+}
+
+func TestRemovedHandlesGoStale(t *testing.T) {
+	w := NewWorld()
+	first := w.NewEntity()
+	w.AddComponent(first, &testPosition{X: 1})
+	w.RemoveEntity(first)
+
+	reused := w.NewEntity()
+	if reused.Index() != first.Index() || reused.Generation() != first.Generation()+1 {
+		t.Fatalf("reused entity is index %d generation %d, want index %d generation %d",
+			reused.Index(), reused.Generation(), first.Index(), first.Generation()+1)
+	}
+	if w.IsAlive(first) {
+		t.Error("the removed handle is alive after its index was reused")
+	}
+
+	w.AddComponent(first, &testPosition{X: 2})
+	Add(w, first, &testVelocity{DX: 2})
+	if Has[testPosition](w, reused) || Has[testVelocity](w, reused) {
+		t.Error("components added through the stale handle went to the entity reusing its index")
+	}
+
+	w.RemoveEntity(first)
+	if !w.IsAlive(reused) {
+		t.Error("removing through the stale handle removed the entity reusing its index")
+	}
+}
+
+func TestNewWorldFromAllocationKeepsHandles(t *testing.T) {
+	w := NewWorld()
+	entities := make([]Entity, 6)
+	for i := range entities {
+		entities[i] = w.NewEntity()
+	}
+	w.RemoveEntity(entities[1])
+	w.RemoveEntity(entities[4])
+	entities[1] = w.NewEntity() // Generation 1 of index 4, the last one freed
+	w.RemoveEntity(entities[1])
+	w.RemoveEntity(entities[2])
+
+	restored, err := NewWorldFromAllocation(w.Allocation())
+	if err != nil {
+		t.Fatalf("NewWorldFromAllocation: %v", err)
+	}
+
+	for _, entity := range entities {
+		if restored.IsAlive(entity) != w.IsAlive(entity) {
+			t.Errorf("entity %d (index %d, generation %d) alive = %v, want %v",
+				entity, entity.Index(), entity.Generation(), restored.IsAlive(entity), w.IsAlive(entity))
+		}
+	}
+
+	// Both worlds must hand out the same handles from now on
+	for range 4 {
+		if got, want := restored.NewEntity(), w.NewEntity(); got != want {
+			t.Errorf("restored world created index %d generation %d, want index %d generation %d",
+				got.Index(), got.Generation(), want.Index(), want.Generation())
+		}
+	}
+}
+
+func TestNewWorldFromAllocationRejectsInconsistentAllocations(t *testing.T) {
+	tests := []struct {
+		name       string
+		allocation Allocation
+	}{
+		{
+			name:       "missing alive flags",
+			allocation: Allocation{Generations: []uint32{0, 0}, Alive: []bool{true}},
+		},
+		{
+			name:       "free index out of range",
+			allocation: Allocation{Generations: []uint32{1}, Alive: []bool{false}, Free: []uint32{0, 1}},
+		},
+		{
+			name:       "alive index is free",
+			allocation: Allocation{Generations: []uint32{0, 1}, Alive: []bool{true, false}, Free: []uint32{1, 0}},
+		},
+		{
+			name:       "index freed twice",
+			allocation: Allocation{Generations: []uint32{1}, Alive: []bool{false}, Free: []uint32{0, 0}},
+		},
+		{
+			name:       "index neither alive nor free",
+			allocation: Allocation{Generations: []uint32{0, 1}, Alive: []bool{true, false}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewWorldFromAllocation(tt.allocation); err == nil {
+				t.Error("NewWorldFromAllocation succeeded")
+			}
+		})
+	}
+}
+
+func TestCommandBufferAppliesOnFlush(t *testing.T) {
+	w := NewWorld()
+	kept := w.NewEntity()
+	destroyed := w.NewEntity()
+	Add(w, kept, &testVelocity{DX: 1})
+	Add(w, destroyed, &testPosition{})
+
+	commands := w.Commands()
+	created := commands.Create()
+	QueueAdd(commands, created, &testPosition{X: 3})
+	QueueAdd(commands, kept, &testPosition{X: 1})
+	QueueRemove[testVelocity](commands, kept)
+	commands.Destroy(destroyed)
+	if commands.Len() != 4 {
+		t.Errorf("Len = %d, want 4", commands.Len())
+	}
+
+	if Has[testPosition](w, created) || Has[testPosition](w, kept) || !Has[testVelocity](w, kept) || !w.IsAlive(destroyed) {
+		t.Fatal("queued commands were applied before the flush")
+	}
+
+	w.Flush()
+	if commands.Len() != 0 {
+		t.Errorf("Len = %d after the flush, want 0", commands.Len())
+	}
+	if position, ok := Get[testPosition](w, created); !ok || position.X != 3 {
+		t.Errorf("created entity position = %v, %v, want X 3", position, ok)
+	}
+	if position, ok := Get[testPosition](w, kept); !ok || position.X != 1 {
+		t.Errorf("kept entity position = %v, %v, want X 1", position, ok)
+	}
+	if Has[testVelocity](w, kept) {
+		t.Error("queued removal of the velocity was not applied")
+	}
+	if w.IsAlive(destroyed) || Has[testPosition](w, destroyed) {
+		t.Error("queued destruction was not applied")
+	}
+}
+
+func TestCommandBufferAppliesInOrder(t *testing.T) {
+	w := NewWorld()
+	entity := w.NewEntity()
+
+	commands := w.Commands()
+	QueueAdd(commands, entity, &testPosition{X: 1})
+	QueueRemove[testPosition](commands, entity)
+	QueueAdd(commands, entity, &testPosition{X: 2})
+	commands.Destroy(entity)
+	QueueAdd(commands, entity, &testPosition{X: 3}) // Stale by the time it is applied
+	w.Flush()
+
+	if w.IsAlive(entity) || Has[testPosition](w, entity) {
+		t.Error("entity survived its queued destruction")
+	}
+
+	// The destroyed index is reused, and the stale queued add must not reach the new entity
+	reused := w.NewEntity()
+	if reused.Index() != entity.Index() {
+		t.Fatalf("index %d was not reused, got %d", entity.Index(), reused.Index())
+	}
+	if Has[testPosition](w, reused) {
+		t.Error("the add queued for the destroyed entity reached the entity reusing its index")
+	}
+}
+
+func TestCommandBufferAppliesCommandsQueuedWhileFlushing(t *testing.T) {
+	w := NewWorld()
+	entity := w.NewEntity()
+
+	commands := w.Commands()
+	commands.push(func(w *World) {
+		QueueAdd(w.Commands(), entity, &testVelocity{DX: 1})
+	})
+	w.Flush()
+
+	if !Has[testVelocity](w, entity) {
+		t.Error("command queued while flushing was not applied by the same flush")
+	}
+	if commands.Len() != 0 {
+		t.Errorf("Len = %d after the flush, want 0", commands.Len())
+	}
+}
+
+// mapWorld is the map-based component storage the stores replaced, kept as a baseline
+// for the benchmarks. Its iteration order is the random order of Go maps.
+type mapWorld struct {
+	components map[reflect.Type]map[Entity]Component
+}
+
+func newMapWorld(w *World) *mapWorld {
+	m := &mapWorld{components: make(map[reflect.Type]map[Entity]Component)}
+	for _, componentType := range []reflect.Type{positionType, velocityType} {
+		for _, entry := range w.GetComponents(componentType) {
+			m.add(entry.Entity, entry.Component)
+		}
+	}
+	return m
+}
+
+func (m *mapWorld) add(entity Entity, component Component) {
+	componentType := reflect.TypeOf(component)
+	if m.components[componentType] == nil {
+		m.components[componentType] = make(map[Entity]Component)
+	}
+	m.components[componentType][entity] = component
+}
+
+func (m *mapWorld) remove(entity Entity) {
+	for _, componentMap := range m.components {
+		delete(componentMap, entity)
+	}
+}
+
+func (m *mapWorld) query(types ...reflect.Type) []Entity {
+	matching := make(map[Entity]bool)
+	for e := range m.components[types[0]] {
+		matching[e] = true
+	}
+	for _, t := range types[1:] {
+		for e := range matching {
+			if _, exists := m.components[t][e]; !exists {
+				delete(matching, e)
+			}
+		}
+	}
+
+	result := make([]Entity, 0, len(matching))
+	for e := range matching {
+		result = append(result, e)
+	}
+	return result
+}
+
+func BenchmarkMapQuery(b *testing.B) {
+	w := NewWorld()
+	populate(w, 1000, 1)
+	m := newMapWorld(w)
+
+	b.ResetTimer()
+	for range b.N {
+		m.query(positionType, velocityType)
+	}
+}
+
+func BenchmarkMapGetComponents(b *testing.B) {
+	w := NewWorld()
+	populate(w, 1000, 1)
+	m := newMapWorld(w)
+
+	b.ResetTimer()
+	for range b.N {
+		for range m.components[positionType] {
+		}
+	}
+}
+
+func BenchmarkMapAdd(b *testing.B) {
+	w := NewWorld()
+	entities := make([]Entity, 1000)
+	for i := range entities {
+		entities[i] = w.NewEntity()
+	}
+	component := &testPosition{}
+
+	b.ResetTimer()
+	for range b.N {
+		m := &mapWorld{components: make(map[reflect.Type]map[Entity]Component)}
+		for _, entity := range entities {
+			m.add(entity, component)
+		}
+	}
+}
+
+func BenchmarkMapRemove(b *testing.B) {
+	w := NewWorld()
+	entities := make([]Entity, 1000)
+	for i := range entities {
+		entities[i] = w.NewEntity()
+	}
+	component := &testPosition{}
+
+	for range b.N {
+		b.StopTimer()
+		m := &mapWorld{components: make(map[reflect.Type]map[Entity]Component)}
+		for _, entity := range entities {
+			m.add(entity, component)
+		}
+		b.StartTimer()
+
+		for _, entity := range entities {
+			m.remove(entity)
+		}
+	}
+}
@@ -1,4 +1,4 @@
-package components
+package mazelayout
 
 type cellType int
 
@@ -1,4 +1,6 @@
-package components
+// Package mazelayout holds the grid of cells a maze is made of. It has no rendering
+// dependencies, so tools like cmd/mazegen can build and inspect mazes without the game.
+package mazelayout
 
 // Layout represents a maze with a 2D grid of cells.
 type Layout struct {
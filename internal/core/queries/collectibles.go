@@ -0,0 +1,17 @@
+package queries
+
+import (
+	"github.com/juanancid/maze-adventure/internal/core/components"
+	"github.com/juanancid/maze-adventure/internal/core/entities"
+)
+
+// CountScoreCollectibles returns how many collectibles worth points are left in the world
+func CountScoreCollectibles(world *entities.World) int {
+	count := 0
+	for _, collectible := range entities.All[components.Collectible](world) {
+		if collectible.Kind == components.CollectibleScore {
+			count++
+		}
+	}
+	return count
+}
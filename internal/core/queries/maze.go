@@ -1,39 +1,27 @@
 package queries
 
 import (
-	"reflect"
-
 	"github.com/juanancid/maze-adventure/internal/core/components"
 	"github.com/juanancid/maze-adventure/internal/core/entities"
 )
 
 func GetMazeComponent(world *entities.World) (*components.Maze, bool) {
-	mazeType := reflect.TypeOf(&components.Maze{})
-	mazes := world.Query(mazeType)
-	if len(mazes) == 0 {
-		return nil, false
+	for _, maze := range entities.All[components.Maze](world) {
+		return maze, true
 	}
-
-	comp := world.GetComponent(mazes[0], mazeType).(*components.Maze)
-	return comp, true
+	return nil, false
 }
 
 func GetPlayerEntity(world *entities.World) (entities.Entity, bool) {
-	playerType := reflect.TypeOf(&components.InputControlled{})
-	players := world.Query(playerType)
-	if len(players) == 0 {
-		return 0, false
+	for player := range entities.All[components.InputControlled](world) {
+		return player, true
 	}
-
-	return players[0], true
+	return 0, false
 }
 
 func GetExitEntity(world *entities.World) (entities.Entity, bool) {
-	exitType := reflect.TypeOf(&components.Exit{})
-	exits := world.Query(exitType)
-	if len(exits) == 0 {
-		return 0, false
+	for exit := range entities.All[components.Exit](world) {
+		return exit, true
 	}
-
-	return exits[0], true
+	return 0, false
 }
@@ -0,0 +1,86 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimulationTicks(t *testing.T) {
+	s := NewSimulation(50)
+	for range 3 {
+		s.Tick()
+	}
+
+	if s.Now() != 60*time.Millisecond || s.Delta() != 20*time.Millisecond || s.Ticks() != 3 {
+		t.Errorf("after 3 ticks now=%v delta=%v ticks=%d, want 60ms, 20ms and 3", s.Now(), s.Delta(), s.Ticks())
+	}
+}
+
+func TestSimulationPause(t *testing.T) {
+	s := NewSimulation(50)
+	s.Tick()
+	s.Pause()
+	s.Tick()
+
+	if !s.IsPaused() || s.Now() != 20*time.Millisecond || s.Delta() != 0 || s.Ticks() != 1 {
+		t.Errorf("paused clock now=%v delta=%v ticks=%d, want 20ms, 0 and 1", s.Now(), s.Delta(), s.Ticks())
+	}
+
+	s.Resume()
+	s.Tick()
+	if s.IsPaused() || s.Now() != 40*time.Millisecond || s.Delta() != 20*time.Millisecond {
+		t.Errorf("resumed clock now=%v delta=%v, want 40ms and 20ms", s.Now(), s.Delta())
+	}
+}
+
+func TestSimulationScale(t *testing.T) {
+	tests := []struct {
+		scale float64
+		want  float64 // Scale the clock ends up with
+		delta time.Duration
+	}{
+		{scale: 1, want: 1, delta: 20 * time.Millisecond},
+		{scale: 0.5, want: 0.5, delta: 10 * time.Millisecond},
+		{scale: 2, want: 2, delta: 40 * time.Millisecond},
+		{scale: 0, want: 0, delta: 0},
+		{scale: -1, want: 0, delta: 0},
+	}
+
+	for _, tt := range tests {
+		s := NewSimulation(50)
+		s.SetScale(tt.scale)
+		s.Tick()
+		s.Tick()
+
+		if s.Scale() != tt.want {
+			t.Errorf("SetScale(%v): scale = %v, want %v", tt.scale, s.Scale(), tt.want)
+		}
+		if s.Delta() != tt.delta || s.Now() != 2*tt.delta {
+			t.Errorf("SetScale(%v): delta=%v now=%v, want %v and %v", tt.scale, s.Delta(), s.Now(), tt.delta, 2*tt.delta)
+		}
+	}
+}
+
+func TestSimulationSet(t *testing.T) {
+	s := NewSimulation(50)
+	s.Tick()
+	s.Set(5 * time.Second)
+
+	if s.Now() != 5*time.Second || s.Delta() != 0 {
+		t.Errorf("after Set now=%v delta=%v, want 5s and 0", s.Now(), s.Delta())
+	}
+}
+
+func TestFake(t *testing.T) {
+	var c Clock = NewFake()
+	if c.Now() != 0 || c.Delta() != 0 {
+		t.Fatalf("new fake clock now=%v delta=%v, want 0", c.Now(), c.Delta())
+	}
+
+	fake := c.(*Fake)
+	fake.Advance(time.Second)
+	fake.Advance(250 * time.Millisecond)
+	if c.Now() != 1250*time.Millisecond || c.Delta() != 250*time.Millisecond {
+		t.Errorf("fake clock now=%v delta=%v, want 1.25s and 250ms", c.Now(), c.Delta())
+	}
+}
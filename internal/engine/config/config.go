@@ -5,4 +5,6 @@ const (
 	ScreenHeight = 270
 	HudHeight    = 40
 	ScaleFactor  = 3
+
+	TicksPerSecond = 60 // Game updates per second, Ebitengine's default
 )
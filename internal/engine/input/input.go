@@ -8,15 +8,17 @@ import (
 
 // Handler manages input state and provides methods to check for key events
 type Handler struct {
-	previousKeyStates map[ebiten.Key]bool
-	combinationStates map[string]bool
+	previousKeyStates    map[ebiten.Key]bool
+	previousButtonStates map[ebiten.StandardGamepadButton]bool
+	combinationStates    map[string]bool
 }
 
 // NewHandler creates a new input handler
 func NewHandler() *Handler {
 	return &Handler{
-		previousKeyStates: make(map[ebiten.Key]bool),
-		combinationStates: make(map[string]bool),
+		previousKeyStates:    make(map[ebiten.Key]bool),
+		previousButtonStates: make(map[ebiten.StandardGamepadButton]bool),
+		combinationStates:    make(map[string]bool),
 	}
 }
 
@@ -36,6 +38,39 @@ func (h *Handler) IsKeyJustPressed(key ebiten.Key) bool {
 	return currentState && !previousState
 }
 
+// Prime records the current state of the keys, so keys already held down when a screen
+// opens are not reported as just pressed until they are released and pressed again
+func (h *Handler) Prime(keys ...ebiten.Key) {
+	for _, key := range keys {
+		h.previousKeyStates[key] = ebiten.IsKeyPressed(key)
+	}
+}
+
+// IsGamepadButtonJustPressed returns true if the button was just pressed this frame on any gamepad
+func (h *Handler) IsGamepadButtonJustPressed(button ebiten.StandardGamepadButton) bool {
+	currentState := IsGamepadButtonPressed(button)
+	previousState := h.previousButtonStates[button]
+	h.previousButtonStates[button] = currentState
+	return currentState && !previousState
+}
+
+// PrimeButtons records the current state of the gamepad buttons, like Prime does for keys
+func (h *Handler) PrimeButtons(buttons ...ebiten.StandardGamepadButton) {
+	for _, button := range buttons {
+		h.previousButtonStates[button] = IsGamepadButtonPressed(button)
+	}
+}
+
+// IsGamepadButtonPressed returns true if the button is held down on any connected gamepad with a standard layout
+func IsGamepadButtonPressed(button ebiten.StandardGamepadButton) bool {
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		if ebiten.IsStandardGamepadLayoutAvailable(id) && ebiten.IsStandardGamepadButtonPressed(id, button) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsKeyCombinationToggled returns true if the key combination was just pressed this frame,
 // implementing a toggle behavior. The order of keys in the combination does not matter
 // (e.g., Meta+D is the same as D+Meta).
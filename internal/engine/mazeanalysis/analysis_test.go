@@ -0,0 +1,248 @@
+package mazeanalysis_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
+	"github.com/juanancid/maze-adventure/internal/engine/mazeanalysis"
+	"github.com/juanancid/maze-adventure/internal/engine/mazeascii"
+)
+
+// Hand-drawn layouts, in the format of the level files
+const (
+	// A perfect maze with a junction on the way out and a deadly cell off the solution
+	tree = `
++---+---+---+
+| S |   | E |
++   +   +   +
+|           |
++---+   +---+
+|     D     |
++---+---+---+
+`
+	// The short way crosses a deadly cell, the long way around is clear
+	detour = `
++---+---+---+
+| S   D   E |
++   +   +   +
+|           |
++---+---+---+
+`
+	// Both ways cross a hazard
+	blocked = `
++---+---+---+
+| S   D   E |
++   +---+   +
+|     F     |
++---+---+---+
+`
+	// The short way crosses two deadly cells, the long way a single freezing one
+	fewerHazards = `
++---+---+---+---+
+| S   D   D   E |
++   +---+---+   +
+|         F     |
++---+---+---+---+
+`
+	// The start is walled in
+	walledIn = `
++---+---+
+| S |   |
++---+---+
+| E     |
++---+---+
+`
+)
+
+func parse(t *testing.T, text string) (mazelayout.Layout, mazeanalysis.Point, mazeanalysis.Point) {
+	t.Helper()
+
+	m, err := mazeascii.Parse(text)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return m.Layout, *m.Start, *m.Exit
+}
+
+func TestShortestPath(t *testing.T) {
+	layout, start, exit := parse(t, tree)
+
+	path, ok := mazeanalysis.ShortestPath(layout, start, exit)
+	want := []mazeanalysis.Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 0}}
+	if !ok || !reflect.DeepEqual(path, want) {
+		t.Errorf("ShortestPath = %v, %v, want %v", path, ok, want)
+	}
+
+	if path, ok := mazeanalysis.ShortestPath(layout, start, start); !ok || len(path) != 1 {
+		t.Errorf("ShortestPath to the start itself = %v, %v, want a single cell", path, ok)
+	}
+	if _, ok := mazeanalysis.ShortestPath(layout, start, mazeanalysis.Point{X: 3, Y: 0}); ok {
+		t.Error("ShortestPath found a path to a cell outside the layout")
+	}
+
+	layout, start, exit = parse(t, walledIn)
+	if _, ok := mazeanalysis.ShortestPath(layout, start, exit); ok {
+		t.Error("ShortestPath found a way out of a walled-in start")
+	}
+}
+
+func TestShortestPathAvoiding(t *testing.T) {
+	layout, start, exit := parse(t, detour)
+	notDeadly := func(p mazeanalysis.Point) bool {
+		return !layout.GetCell(p.X, p.Y).IsDeadly()
+	}
+
+	path, ok := mazeanalysis.ShortestPathAvoiding(layout, start, exit, notDeadly)
+	want := []mazeanalysis.Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 0}}
+	if !ok || !reflect.DeepEqual(path, want) {
+		t.Errorf("ShortestPathAvoiding = %v, %v, want %v", path, ok, want)
+	}
+
+	layout, start, exit = parse(t, blocked)
+	notHazard := func(p mazeanalysis.Point) bool {
+		return !mazeanalysis.IsHazard(layout.GetCell(p.X, p.Y))
+	}
+	if path, ok := mazeanalysis.ShortestPathAvoiding(layout, start, exit, notHazard); ok {
+		t.Errorf("ShortestPathAvoiding = %v through blocked hazards", path)
+	}
+}
+
+func TestAStar(t *testing.T) {
+	layout, start, exit := parse(t, fewerHazards)
+
+	// Unit costs find a shortest path, like the breadth-first search
+	path, cost, ok := mazeanalysis.AStar(layout, start, exit, nil)
+	shortest, _ := mazeanalysis.ShortestPath(layout, start, exit)
+	if !ok || len(path) != len(shortest) || cost != float64(len(shortest)-1) {
+		t.Errorf("AStar = %v, cost %v, %v, want %d steps", path, cost, ok, len(shortest)-1)
+	}
+
+	// Costly hazards send it the long way around
+	hazardCost := func(_ mazeanalysis.Point, cell mazelayout.Cell) float64 {
+		if mazeanalysis.IsHazard(cell) {
+			return 10
+		}
+		return 1
+	}
+	path, cost, ok = mazeanalysis.AStar(layout, start, exit, hazardCost)
+	want := []mazeanalysis.Point{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 3, Y: 1}, {X: 3, Y: 0}}
+	if !ok || !reflect.DeepEqual(path, want) || cost != 14 {
+		t.Errorf("AStar = %v, cost %v, %v, want %v, cost 14", path, cost, ok, want)
+	}
+
+	layout, start, exit = parse(t, walledIn)
+	if _, _, ok := mazeanalysis.AStar(layout, start, exit, nil); ok {
+		t.Error("AStar found a way out of a walled-in start")
+	}
+}
+
+func TestCountTopology(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout string
+		want   mazeanalysis.Topology
+		loops  int
+	}{
+		{
+			name:   "tree",
+			layout: tree,
+			want:   mazeanalysis.Topology{DeadEnds: 5, Corridors: 2, Junctions: 2, Passages: 8},
+		},
+		{
+			name:   "detour",
+			layout: detour,
+			want:   mazeanalysis.Topology{Corridors: 4, Junctions: 2, Passages: 7},
+			loops:  2,
+		},
+		{
+			name:   "walled in",
+			layout: walledIn,
+			want:   mazeanalysis.Topology{Isolated: 2, DeadEnds: 2, Passages: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout, _, _ := parse(t, tt.layout)
+
+			topology := mazeanalysis.CountTopology(layout)
+			if topology != tt.want {
+				t.Errorf("CountTopology = %+v, want %+v", topology, tt.want)
+			}
+			if loops := topology.Loops(layout.Cols() * layout.Rows()); loops != tt.loops {
+				t.Errorf("Loops = %d, want %d", loops, tt.loops)
+			}
+		})
+	}
+}
+
+func TestDeadEnds(t *testing.T) {
+	layout, _, _ := parse(t, tree)
+
+	want := []mazeanalysis.Point{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 0, Y: 2}, {X: 2, Y: 2}}
+	if deadEnds := mazeanalysis.DeadEnds(layout); !reflect.DeepEqual(deadEnds, want) {
+		t.Errorf("DeadEnds = %v, want %v", deadEnds, want)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	layout, start, exit := parse(t, tree)
+
+	report := mazeanalysis.Analyze(layout, start, exit)
+	if !report.Solvable || report.SolutionLength != 4 || report.Reachable != 9 || report.Loops != 0 {
+		t.Errorf("solvable %v, length %d, reachable %d, loops %d, want true, 4, 9, 0",
+			report.Solvable, report.SolutionLength, report.Reachable, report.Loops)
+	}
+	if report.DecisionPoints != 1 || report.Tortuosity != 2 {
+		t.Errorf("decision points %d, tortuosity %v, want 1, 2", report.DecisionPoints, report.Tortuosity)
+	}
+	if report.HazardsOnPath != 0 || report.UnavoidableHazards != 0 {
+		t.Errorf("hazards on path %d, unavoidable %d, want 0, 0", report.HazardsOnPath, report.UnavoidableHazards)
+	}
+	if report.Difficulty <= 0 || report.Difficulty > 100 {
+		t.Errorf("difficulty %v, want between 0 and 100", report.Difficulty)
+	}
+
+	layout, start, exit = parse(t, walledIn)
+	report = mazeanalysis.Analyze(layout, start, exit)
+	if report.Solvable || report.SolutionLength != mazeanalysis.Unreachable || report.Reachable != 1 {
+		t.Errorf("solvable %v, length %d, reachable %d, want false, unreachable, 1",
+			report.Solvable, report.SolutionLength, report.Reachable)
+	}
+}
+
+func TestAnalyzeCountsCriticalPathHazards(t *testing.T) {
+	tests := []struct {
+		name        string
+		layout      string
+		length      int
+		deadly      int
+		freezing    int
+		unavoidable int
+	}{
+		{name: "clear", layout: tree, length: 4},
+		{name: "detour", layout: detour, length: 2, deadly: 1},
+		{name: "blocked", layout: blocked, length: 2, deadly: 1, unavoidable: 1},
+		{name: "fewer hazards the long way", layout: fewerHazards, length: 3, deadly: 2, unavoidable: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout, start, exit := parse(t, tt.layout)
+
+			report := mazeanalysis.Analyze(layout, start, exit)
+			if report.SolutionLength != tt.length {
+				t.Errorf("SolutionLength = %d, want %d", report.SolutionLength, tt.length)
+			}
+			if report.DeadlyOnPath != tt.deadly || report.FreezingOnPath != tt.freezing ||
+				report.HazardsOnPath != tt.deadly+tt.freezing {
+				t.Errorf("on path: deadly %d, freezing %d, hazards %d, want %d, %d, %d",
+					report.DeadlyOnPath, report.FreezingOnPath, report.HazardsOnPath, tt.deadly, tt.freezing, tt.deadly+tt.freezing)
+			}
+			if report.UnavoidableHazards != tt.unavoidable {
+				t.Errorf("UnavoidableHazards = %d, want %d", report.UnavoidableHazards, tt.unavoidable)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package mazeanalysis
+
+import (
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
+)
+
+// Unreachable is the distance reported for cells that cannot be reached
+const Unreachable = -1
+
+// DistanceMap holds the number of steps from an origin cell to every cell of a layout
+type DistanceMap struct {
+	cols      int
+	distances []int
+}
+
+// At returns the distance to p, or Unreachable
+func (d DistanceMap) At(p Point) int {
+	return d.distances[p.Y*d.cols+p.X]
+}
+
+// Farthest returns the reachable cell with the largest distance and that distance
+func (d DistanceMap) Farthest() (Point, int) {
+	farthest, best := 0, Unreachable
+	for i, distance := range d.distances {
+		if distance > best {
+			farthest, best = i, distance
+		}
+	}
+	return Point{X: farthest % d.cols, Y: farthest / d.cols}, best
+}
+
+// Reachable returns the number of cells that can be reached from the origin, including the origin
+func (d DistanceMap) Reachable() int {
+	reachable := 0
+	for _, distance := range d.distances {
+		if distance != Unreachable {
+			reachable++
+		}
+	}
+	return reachable
+}
+
+// Distances computes the distance map from an origin cell using a breadth-first search
+func Distances(layout mazelayout.Layout, from Point) DistanceMap {
+	return DistancesAvoiding(layout, from, nil)
+}
+
+// DistancesAvoiding computes the distance map only walking through passable cells.
+// A nil passable function allows every cell.
+func DistancesAvoiding(layout mazelayout.Layout, from Point, passable PassableFunc) DistanceMap {
+	distances := make([]int, layout.Cols()*layout.Rows())
+	for i := range distances {
+		distances[i] = Unreachable
+	}
+	result := DistanceMap{cols: layout.Cols(), distances: distances}
+
+	if !InBounds(layout, from) || (passable != nil && !passable(from)) {
+		return result
+	}
+
+	distances[index(layout, from)] = 0
+	queue := []Point{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range Neighbors(layout, current) {
+			i := index(layout, next)
+			if distances[i] != Unreachable || (passable != nil && !passable(next)) {
+				continue
+			}
+			distances[i] = distances[index(layout, current)] + 1
+			queue = append(queue, next)
+		}
+	}
+
+	return result
+}
+
+// DistanceMatrix holds the distances between every pair of cells of a layout
+type DistanceMatrix struct {
+	layout mazelayout.Layout
+	maps   []DistanceMap
+}
+
+// AllPairsDistances computes the distance between every pair of cells with one breadth-first search per cell.
+// Mazes are sparse graphs, so this is cheaper than Floyd-Warshall for any layout that fits on screen.
+func AllPairsDistances(layout mazelayout.Layout) DistanceMatrix {
+	maps := make([]DistanceMap, layout.Cols()*layout.Rows())
+	for i := range maps {
+		maps[i] = Distances(layout, pointAt(layout, i))
+	}
+	return DistanceMatrix{layout: layout, maps: maps}
+}
+
+// Distance returns the number of steps between two cells, or Unreachable
+func (m DistanceMatrix) Distance(from, to Point) int {
+	return m.maps[index(m.layout, from)].At(to)
+}
+
+// From returns the distance map of a single origin cell
+func (m DistanceMatrix) From(p Point) DistanceMap {
+	return m.maps[index(m.layout, p)]
+}
+
+// Diameter returns the two cells that are farthest apart and their distance
+func (m DistanceMatrix) Diameter() (Point, Point, int) {
+	var from, to Point
+	best := Unreachable
+	for i, distances := range m.maps {
+		farthest, distance := distances.Farthest()
+		if distance > best {
+			from, to, best = pointAt(m.layout, i), farthest, distance
+		}
+	}
+	return from, to, best
+}
+
+// MultiSourceDistances computes, for every cell, the number of steps to the nearest of the given sources
+func MultiSourceDistances(layout mazelayout.Layout, sources []Point) DistanceMap {
+	distances := make([]int, layout.Cols()*layout.Rows())
+	for i := range distances {
+		distances[i] = Unreachable
+	}
+
+	var queue []Point
+	for _, source := range sources {
+		if !InBounds(layout, source) || distances[index(layout, source)] == 0 {
+			continue
+		}
+		distances[index(layout, source)] = 0
+		queue = append(queue, source)
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, next := range Neighbors(layout, current) {
+			i := index(layout, next)
+			if distances[i] != Unreachable {
+				continue
+			}
+			distances[i] = distances[index(layout, current)] + 1
+			queue = append(queue, next)
+		}
+	}
+
+	return DistanceMap{cols: layout.Cols(), distances: distances}
+}
@@ -0,0 +1,67 @@
+// Package mazeanalysis measures maze layouts: shortest paths, distance maps,
+// topology counts and an overall difficulty score.
+package mazeanalysis
+
+import (
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
+)
+
+// Point is a cell coordinate in a maze layout
+type Point struct {
+	X int // Column
+	Y int // Row
+}
+
+// Direction offsets indexed by wall: 0=top, 1=right, 2=bottom, 3=left
+var (
+	dx = [4]int{0, 1, 0, -1}
+	dy = [4]int{-1, 0, 1, 0}
+)
+
+// InBounds returns true if the point is inside the layout
+func InBounds(layout mazelayout.Layout, p Point) bool {
+	return p.X >= 0 && p.X < layout.Cols() && p.Y >= 0 && p.Y < layout.Rows()
+}
+
+// CanMove returns true if there is an open passage from p in the given direction.
+// Both cells must agree that the wall between them is open.
+func CanMove(layout mazelayout.Layout, p Point, dir int) bool {
+	next := Point{X: p.X + dx[dir], Y: p.Y + dy[dir]}
+	if !InBounds(layout, p) || !InBounds(layout, next) {
+		return false
+	}
+
+	return !layout.GetCell(p.X, p.Y).GetWalls()[dir] && !layout.GetCell(next.X, next.Y).GetWalls()[(dir+2)%4]
+}
+
+// Neighbors returns the cells reachable from p in a single step
+func Neighbors(layout mazelayout.Layout, p Point) []Point {
+	neighbors := make([]Point, 0, 4)
+	for dir := 0; dir < 4; dir++ {
+		if CanMove(layout, p, dir) {
+			neighbors = append(neighbors, Point{X: p.X + dx[dir], Y: p.Y + dy[dir]})
+		}
+	}
+	return neighbors
+}
+
+// Openings returns the number of open passages of the cell at p
+func Openings(layout mazelayout.Layout, p Point) int {
+	openings := 0
+	for dir := 0; dir < 4; dir++ {
+		if CanMove(layout, p, dir) {
+			openings++
+		}
+	}
+	return openings
+}
+
+// index converts a point into a flat cell index
+func index(layout mazelayout.Layout, p Point) int {
+	return p.Y*layout.Cols() + p.X
+}
+
+// pointAt converts a flat cell index into a point
+func pointAt(layout mazelayout.Layout, i int) Point {
+	return Point{X: i % layout.Cols(), Y: i / layout.Cols()}
+}
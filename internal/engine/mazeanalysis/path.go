@@ -0,0 +1,163 @@
+package mazeanalysis
+
+import (
+	"container/heap"
+
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
+)
+
+// PassableFunc reports whether a path may go through the cell at p
+type PassableFunc func(p Point) bool
+
+// CostFunc returns the cost of stepping into the cell at p. Costs must be at least 1
+// so that the Manhattan distance remains an admissible A* heuristic.
+type CostFunc func(p Point, cell mazelayout.Cell) float64
+
+// ShortestPath returns the shortest path from one cell to another using a breadth-first search.
+// The path includes both ends. It returns false if the target cannot be reached.
+func ShortestPath(layout mazelayout.Layout, from, to Point) ([]Point, bool) {
+	return ShortestPathAvoiding(layout, from, to, nil)
+}
+
+// ShortestPathAvoiding returns the shortest path that only goes through passable cells.
+// A nil passable function allows every cell. The ends of the path must be passable too.
+func ShortestPathAvoiding(layout mazelayout.Layout, from, to Point, passable PassableFunc) ([]Point, bool) {
+	if !InBounds(layout, from) || !InBounds(layout, to) {
+		return nil, false
+	}
+	if passable != nil && (!passable(from) || !passable(to)) {
+		return nil, false
+	}
+
+	previous := make([]int, layout.Cols()*layout.Rows())
+	for i := range previous {
+		previous[i] = -1
+	}
+
+	start := index(layout, from)
+	previous[start] = start
+	queue := []Point{from}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == to {
+			return buildPath(layout, previous, index(layout, to)), true
+		}
+
+		for _, next := range Neighbors(layout, current) {
+			i := index(layout, next)
+			if previous[i] != -1 || (passable != nil && !passable(next)) {
+				continue
+			}
+			previous[i] = index(layout, current)
+			queue = append(queue, next)
+		}
+	}
+
+	return nil, false
+}
+
+// AStar returns the cheapest path between two cells and its total cost, where the cost of a path is
+// the sum of the costs of every cell entered after the start. A nil cost function costs 1 per step.
+// It returns false if the target cannot be reached.
+func AStar(layout mazelayout.Layout, from, to Point, cost CostFunc) ([]Point, float64, bool) {
+	if !InBounds(layout, from) || !InBounds(layout, to) {
+		return nil, 0, false
+	}
+	if cost == nil {
+		cost = func(Point, mazelayout.Cell) float64 { return 1 }
+	}
+
+	cells := layout.Cols() * layout.Rows()
+	previous := make([]int, cells)
+	bestCost := make([]float64, cells)
+	for i := range previous {
+		previous[i] = -1
+		bestCost[i] = -1
+	}
+
+	start := index(layout, from)
+	previous[start] = start
+	bestCost[start] = 0
+
+	open := &openSet{{point: from, priority: manhattan(from, to)}}
+	for open.Len() > 0 {
+		current := heap.Pop(open).(openNode)
+		currentIndex := index(layout, current.point)
+
+		if current.point == to {
+			return buildPath(layout, previous, currentIndex), bestCost[currentIndex], true
+		}
+
+		// Skip stale entries that were superseded by a cheaper route.
+		if current.priority > bestCost[currentIndex]+manhattan(current.point, to) {
+			continue
+		}
+
+		for _, next := range Neighbors(layout, current.point) {
+			i := index(layout, next)
+			nextCost := bestCost[currentIndex] + cost(next, layout.GetCell(next.X, next.Y))
+			if bestCost[i] >= 0 && nextCost >= bestCost[i] {
+				continue
+			}
+			bestCost[i] = nextCost
+			previous[i] = currentIndex
+			heap.Push(open, openNode{point: next, priority: nextCost + manhattan(next, to)})
+		}
+	}
+
+	return nil, 0, false
+}
+
+// buildPath walks the previous links back from the target to the start
+func buildPath(layout mazelayout.Layout, previous []int, target int) []Point {
+	var path []Point
+	for i := target; ; i = previous[i] {
+		path = append(path, pointAt(layout, i))
+		if previous[i] == i {
+			break
+		}
+	}
+
+	for left, right := 0, len(path)-1; left < right; left, right = left+1, right-1 {
+		path[left], path[right] = path[right], path[left]
+	}
+	return path
+}
+
+func manhattan(a, b Point) float64 {
+	return float64(abs(a.X-b.X) + abs(a.Y-b.Y))
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// openNode is an A* frontier entry
+type openNode struct {
+	point    Point
+	priority float64
+}
+
+// openSet is a min-heap of frontier entries ordered by priority
+type openSet []openNode
+
+func (o openSet) Len() int           { return len(o) }
+func (o openSet) Less(i, j int) bool { return o[i].priority < o[j].priority }
+func (o openSet) Swap(i, j int)      { o[i], o[j] = o[j], o[i] }
+
+func (o *openSet) Push(x any) {
+	*o = append(*o, x.(openNode))
+}
+
+func (o *openSet) Pop() any {
+	old := *o
+	n := old[len(old)-1]
+	*o = old[:len(old)-1]
+	return n
+}
@@ -0,0 +1,140 @@
+package mazeanalysis
+
+import (
+	"math"
+
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
+)
+
+// Weights of each factor in the difficulty score. They add up to 1.
+const (
+	sizeWeight       = 0.20 // Bigger mazes take longer to explore
+	tortuosityWeight = 0.20 // Solutions that wander far from the straight line
+	decisionWeight   = 0.25 // Junctions along the solution where the player can go wrong
+	deadEndWeight    = 0.15 // Dead ends that punish wrong choices
+	hazardWeight     = 0.20 // Hazards the player has to cross or dodge on the way out
+)
+
+// Reference values at which each factor saturates
+const (
+	referenceCells      = 200.0 // Cells of a large on-screen maze
+	referenceTortuosity = 4.0   // Solution four times longer than the Manhattan distance
+	referenceDecisions  = 0.5   // One junction every other step of the solution
+	referenceDeadEnds   = 0.25  // A quarter of the cells are dead ends
+	referenceHazards    = 10.0  // Weighted hazards on the way out
+)
+
+// Report summarizes the measurements of a maze between a start and an exit cell
+type Report struct {
+	Cols int
+	Rows int
+	Topology
+
+	Loops     int // Passages beyond those of a spanning tree
+	Reachable int // Cells reachable from the start, including the start
+
+	Solvable       bool    // Whether the exit can be reached from the start
+	SolutionPath   []Point // Shortest path from start to exit, both included
+	SolutionLength int     // Steps of the shortest path, Unreachable if the exit cannot be reached
+	Tortuosity     float64 // Solution length divided by the Manhattan distance
+	DecisionPoints int     // Junctions along the solution path, excluding the exit
+
+	DeadlyOnPath       int // Deadly cells on the shortest path
+	FreezingOnPath     int // Freezing cells on the shortest path
+	HazardsOnPath      int // Deadly and freezing cells on the shortest path
+	UnavoidableHazards int // Fewest hazards any path from start to exit has to cross
+
+	Difficulty float64 // Composite score between 0 (trivial) and 100 (hardest)
+}
+
+// IsHazard returns true if the cell affects the player when entered
+func IsHazard(cell mazelayout.Cell) bool {
+	return cell.IsDeadly() || cell.IsFreezing()
+}
+
+// Analyze measures the layout for a player travelling from start to exit
+func Analyze(layout mazelayout.Layout, start, exit Point) Report {
+	cells := layout.Cols() * layout.Rows()
+	topology := CountTopology(layout)
+
+	report := Report{
+		Cols:           layout.Cols(),
+		Rows:           layout.Rows(),
+		Topology:       topology,
+		Loops:          topology.Loops(cells),
+		Reachable:      Distances(layout, start).Reachable(),
+		SolutionLength: Unreachable,
+	}
+
+	path, found := ShortestPath(layout, start, exit)
+	if !found {
+		return report
+	}
+
+	report.Solvable = true
+	report.SolutionPath = path
+	report.SolutionLength = len(path) - 1
+	if distance := manhattan(start, exit); distance > 0 {
+		report.Tortuosity = float64(report.SolutionLength) / distance
+	}
+
+	for i, p := range path {
+		cell := layout.GetCell(p.X, p.Y)
+		if cell.IsDeadly() {
+			report.DeadlyOnPath++
+		}
+		if cell.IsFreezing() {
+			report.FreezingOnPath++
+		}
+		if i < len(path)-1 && Openings(layout, p) >= 3 {
+			report.DecisionPoints++
+		}
+	}
+	report.HazardsOnPath = report.DeadlyOnPath + report.FreezingOnPath
+
+	// The safest path crosses only the hazards there is no way around: stepping into a hazard
+	// costs more than any path without hazards, which never enters more cells than the maze has
+	safestPath, _, _ := AStar(layout, start, exit, func(_ Point, cell mazelayout.Cell) float64 {
+		if IsHazard(cell) {
+			return float64(cells)
+		}
+		return 1
+	})
+	for _, p := range safestPath {
+		if IsHazard(layout.GetCell(p.X, p.Y)) {
+			report.UnavoidableHazards++
+		}
+	}
+
+	report.Difficulty = difficulty(report, cells)
+	return report
+}
+
+// difficulty combines the normalized factors of a report into a score between 0 and 100
+func difficulty(report Report, cells int) float64 {
+	size := saturate(float64(cells) / referenceCells)
+	tortuosity := 0.0
+	if report.Tortuosity > 1 {
+		tortuosity = saturate((report.Tortuosity - 1) / (referenceTortuosity - 1))
+	}
+	decisions := 0.0
+	if report.SolutionLength > 0 {
+		decisions = saturate(float64(report.DecisionPoints) / float64(report.SolutionLength) / referenceDecisions)
+	}
+	deadEnds := saturate(float64(report.DeadEnds) / float64(cells) / referenceDeadEnds)
+
+	// Unavoidable hazards weigh double: the player cannot route around them.
+	hazards := saturate(float64(2*report.UnavoidableHazards+report.HazardsOnPath) / referenceHazards)
+
+	score := sizeWeight*size +
+		tortuosityWeight*tortuosity +
+		decisionWeight*decisions +
+		deadEndWeight*deadEnds +
+		hazardWeight*hazards
+
+	return math.Round(score*1000) / 10
+}
+
+func saturate(v float64) float64 {
+	return math.Max(0, math.Min(1, v))
+}
@@ -0,0 +1,67 @@
+package mazeanalysis
+
+import (
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
+)
+
+// Topology counts cells by the number of open passages they have
+type Topology struct {
+	Isolated  int // Cells with no openings
+	DeadEnds  int // Cells with a single opening
+	Corridors int // Cells with two openings
+	Junctions int // Cells with three or four openings
+	Passages  int // Number of open walls between pairs of cells
+}
+
+// Loops returns the number of passages beyond those of a spanning tree.
+// A perfect maze has no loops; every extra connection adds one.
+func (t Topology) Loops(cells int) int {
+	loops := t.Passages - (cells - 1)
+	if loops < 0 {
+		return 0
+	}
+	return loops
+}
+
+// CountTopology classifies every cell of a layout
+func CountTopology(layout mazelayout.Layout) Topology {
+	var topology Topology
+	for y := 0; y < layout.Rows(); y++ {
+		for x := 0; x < layout.Cols(); x++ {
+			p := Point{X: x, Y: y}
+			switch openings := Openings(layout, p); {
+			case openings == 0:
+				topology.Isolated++
+			case openings == 1:
+				topology.DeadEnds++
+			case openings == 2:
+				topology.Corridors++
+			default:
+				topology.Junctions++
+			}
+
+			// Count each passage once, from its left or top cell.
+			if CanMove(layout, p, 1) {
+				topology.Passages++
+			}
+			if CanMove(layout, p, 2) {
+				topology.Passages++
+			}
+		}
+	}
+	return topology
+}
+
+// DeadEnds returns every dead-end cell of a layout in row-major order
+func DeadEnds(layout mazelayout.Layout) []Point {
+	var deadEnds []Point
+	for y := 0; y < layout.Rows(); y++ {
+		for x := 0; x < layout.Cols(); x++ {
+			p := Point{X: x, Y: y}
+			if Openings(layout, p) == 1 {
+				deadEnds = append(deadEnds, p)
+			}
+		}
+	}
+	return deadEnds
+}
@@ -0,0 +1,163 @@
+// Package mazeascii reads and writes maze layouts as plain text so they can be drawn by hand.
+//
+// Every cell is three characters wide and walls are drawn with '+', '-' and '|':
+//
+//	+---+---+---+
+//	| S     | D |
+//	+   +---+   +
+//	| C   P   E |
+//	+---+---+---+
+//
+// A cell may hold a cell type marker (D deadly, F freezing) and entity markers
+// (S start, E exit, C collectible, P patroller spawn) anywhere inside its three characters.
+package mazeascii
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
+	"github.com/juanancid/maze-adventure/internal/engine/mazeanalysis"
+)
+
+// Cell and wall markers
+const (
+	MarkerDeadly      = 'D'
+	MarkerFreezing    = 'F'
+	MarkerStart       = 'S'
+	MarkerExit        = 'E'
+	MarkerCollectible = 'C'
+	MarkerPatroller   = 'P'
+
+	corner         = '+'
+	verticalWall   = '|'
+	horizontalWall = "---"
+	openPassage    = "   "
+	cellWidth      = len(horizontalWall)
+)
+
+// Map is a layout along with the cells marked for the level entities
+type Map struct {
+	Layout       mazelayout.Layout
+	Start        *mazeanalysis.Point  // Start cell, nil if it is not marked
+	Exit         *mazeanalysis.Point  // Exit cell, nil if it is not marked
+	Collectibles []mazeanalysis.Point // Collectible cells in reading order
+	Patrollers   []mazeanalysis.Point // Patroller spawn cells in reading order
+}
+
+// FormatLayout draws a layout with its deadly and freezing cells
+func FormatLayout(layout mazelayout.Layout) string {
+	text, err := Format(Map{Layout: layout})
+	if err != nil {
+		// A layout alone holds at most one marker per cell, which always fits
+		panic(err)
+	}
+	return text
+}
+
+// Format draws a map. It fails if a cell has more markers than fit in its three characters.
+func Format(m Map) (string, error) {
+	markers := make(map[mazeanalysis.Point][]byte)
+	mark := func(p mazeanalysis.Point, marker byte) error {
+		if !mazeanalysis.InBounds(m.Layout, p) {
+			return fmt.Errorf("marker %c at %v is outside the %dx%d layout", marker, p, m.Layout.Cols(), m.Layout.Rows())
+		}
+		markers[p] = append(markers[p], marker)
+		return nil
+	}
+
+	for y := 0; y < m.Layout.Rows(); y++ {
+		for x := 0; x < m.Layout.Cols(); x++ {
+			cell := m.Layout.GetCell(x, y)
+			switch {
+			case cell.IsDeadly():
+				markers[mazeanalysis.Point{X: x, Y: y}] = []byte{MarkerDeadly}
+			case cell.IsFreezing():
+				markers[mazeanalysis.Point{X: x, Y: y}] = []byte{MarkerFreezing}
+			}
+		}
+	}
+	if m.Start != nil {
+		if err := mark(*m.Start, MarkerStart); err != nil {
+			return "", err
+		}
+	}
+	if m.Exit != nil {
+		if err := mark(*m.Exit, MarkerExit); err != nil {
+			return "", err
+		}
+	}
+	for _, p := range m.Collectibles {
+		if err := mark(p, MarkerCollectible); err != nil {
+			return "", err
+		}
+	}
+	for _, p := range m.Patrollers {
+		if err := mark(p, MarkerPatroller); err != nil {
+			return "", err
+		}
+	}
+
+	var sb strings.Builder
+	for y := 0; y < m.Layout.Rows(); y++ {
+		writeHorizontalWalls(&sb, m.Layout, y)
+
+		for x := 0; x < m.Layout.Cols(); x++ {
+			cell := m.Layout.GetCell(x, y)
+			writeVerticalWall(&sb, cell.HasLeftWall() || (x > 0 && m.Layout.GetCellLeft(x, y).HasRightWall()))
+
+			interior, err := cellInterior(markers[mazeanalysis.Point{X: x, Y: y}])
+			if err != nil {
+				return "", fmt.Errorf("cell (%d,%d): %w", x, y, err)
+			}
+			sb.WriteString(interior)
+		}
+		if m.Layout.Cols() > 0 {
+			writeVerticalWall(&sb, m.Layout.GetCell(m.Layout.Cols()-1, y).HasRightWall())
+		}
+		sb.WriteByte('\n')
+	}
+	writeHorizontalWalls(&sb, m.Layout, m.Layout.Rows())
+
+	return sb.String(), nil
+}
+
+// writeHorizontalWalls draws the line above row y, y == rows draws the bottom border
+func writeHorizontalWalls(sb *strings.Builder, layout mazelayout.Layout, y int) {
+	sb.WriteByte(corner)
+	for x := 0; x < layout.Cols(); x++ {
+		wall := (y < layout.Rows() && layout.GetCell(x, y).HasTopWall()) ||
+			(y > 0 && layout.GetCell(x, y-1).HasBottomWall())
+		if wall {
+			sb.WriteString(horizontalWall)
+		} else {
+			sb.WriteString(openPassage)
+		}
+		sb.WriteByte(corner)
+	}
+	sb.WriteByte('\n')
+}
+
+func writeVerticalWall(sb *strings.Builder, wall bool) {
+	if wall {
+		sb.WriteByte(verticalWall)
+	} else {
+		sb.WriteByte(' ')
+	}
+}
+
+// cellInterior centers the markers of a cell in its three characters
+func cellInterior(markers []byte) (string, error) {
+	switch len(markers) {
+	case 0:
+		return openPassage, nil
+	case 1:
+		return " " + string(markers) + " ", nil
+	case 2:
+		return string(markers) + " ", nil
+	case cellWidth:
+		return string(markers), nil
+	default:
+		return "", fmt.Errorf("%d markers %q do not fit in a cell", len(markers), markers)
+	}
+}
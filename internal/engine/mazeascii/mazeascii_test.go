@@ -0,0 +1,225 @@
+package mazeascii
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/juanancid/maze-adventure/internal/engine/mazeanalysis"
+	"github.com/juanancid/maze-adventure/internal/engine/mazebuilder"
+)
+
+// handDrawn are maps drawn the way level files hold them, already in the shape Format writes
+var handDrawn = map[string]string{
+	"readme example": `
++---+---+---+
+| S     | D |
++   +---+   +
+| C   P   E |
++---+---+---+
+`,
+	"single cell": `
++---+
+|SE |
++---+
+`,
+	"every marker": `
++---+---+---+---+
+| S   F   D | E |
++---+   +---+   +
+|DC  CP  FP     |
++---+---+---+---+
+`,
+	"open borders": `
++   +---+
+  S     |
++---+   +
+  E     |
++---+---+
+`,
+}
+
+func TestFormatLayoutRoundTripsGeneratedLayouts(t *testing.T) {
+	for _, algorithm := range mazebuilder.Algorithms() {
+		t.Run(string(algorithm), func(t *testing.T) {
+			config := mazebuilder.NewBuilderConfig(9, 7)
+			config.Algorithm = algorithm
+			config.Seed = 42
+			config.DeadlyCells = 3
+			config.FreezingCells = 2
+			config.ExtraConnectionChance = 0.2
+
+			layout, err := mazebuilder.Build(config)
+			if err != nil {
+				t.Fatalf("Build: %v", err)
+			}
+
+			parsed, err := ParseLayout(FormatLayout(layout))
+			if err != nil {
+				t.Fatalf("ParseLayout: %v", err)
+			}
+			if !reflect.DeepEqual(parsed, layout) {
+				t.Errorf("parsed layout differs from the formatted one:\n%s", FormatLayout(parsed))
+			}
+		})
+	}
+}
+
+func TestFormatRoundTripsHandDrawnMaps(t *testing.T) {
+	for name, text := range handDrawn {
+		t.Run(name, func(t *testing.T) {
+			m, err := Parse(text)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+
+			formatted, err := Format(m)
+			if err != nil {
+				t.Fatalf("Format: %v", err)
+			}
+			if formatted != strings.TrimLeft(text, "\n") {
+				t.Errorf("Format wrote\n%s\nwant\n%s", formatted, text)
+			}
+
+			reparsed, err := Parse(formatted)
+			if err != nil {
+				t.Fatalf("Parse of the formatted map: %v", err)
+			}
+			if !reflect.DeepEqual(reparsed, m) {
+				t.Errorf("parsed map %+v, want %+v", reparsed, m)
+			}
+		})
+	}
+}
+
+func TestParseReadsMarkers(t *testing.T) {
+	m, err := Parse(handDrawn["every marker"])
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if want := (mazeanalysis.Point{X: 0, Y: 0}); m.Start == nil || *m.Start != want {
+		t.Errorf("start = %v, want %v", m.Start, want)
+	}
+	if want := (mazeanalysis.Point{X: 3, Y: 0}); m.Exit == nil || *m.Exit != want {
+		t.Errorf("exit = %v, want %v", m.Exit, want)
+	}
+	if want := []mazeanalysis.Point{{X: 0, Y: 1}, {X: 1, Y: 1}}; !reflect.DeepEqual(m.Collectibles, want) {
+		t.Errorf("collectibles = %v, want %v", m.Collectibles, want)
+	}
+	if want := []mazeanalysis.Point{{X: 1, Y: 1}, {X: 2, Y: 1}}; !reflect.DeepEqual(m.Patrollers, want) {
+		t.Errorf("patrollers = %v, want %v", m.Patrollers, want)
+	}
+
+	cells := []struct {
+		x, y               int
+		deadly, freezing   bool
+		top, right, bottom bool
+	}{
+		{x: 1, y: 0, freezing: true, top: true},
+		{x: 2, y: 0, deadly: true, top: true, right: true, bottom: true},
+		{x: 0, y: 1, deadly: true, top: true, bottom: true},
+		{x: 2, y: 1, freezing: true, top: true, bottom: true},
+	}
+	for _, want := range cells {
+		cell := m.Layout.GetCell(want.x, want.y)
+		if cell.IsDeadly() != want.deadly || cell.IsFreezing() != want.freezing {
+			t.Errorf("cell (%d,%d) deadly=%v freezing=%v, want deadly=%v freezing=%v",
+				want.x, want.y, cell.IsDeadly(), cell.IsFreezing(), want.deadly, want.freezing)
+		}
+		if cell.HasTopWall() != want.top || cell.HasRightWall() != want.right || cell.HasBottomWall() != want.bottom {
+			t.Errorf("cell (%d,%d) walls top=%v right=%v bottom=%v, want top=%v right=%v bottom=%v",
+				want.x, want.y, cell.HasTopWall(), cell.HasRightWall(), cell.HasBottomWall(), want.top, want.right, want.bottom)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "even number of lines",
+			text: "+---+\n|   |\n",
+			want: "a maze needs an odd number of lines, at least 3, got 2",
+		},
+		{
+			name: "bad width",
+			text: "\n\n+----+\n|    |\n+----+\n",
+			want: "line 3: a maze line needs 4 characters per cell plus one, got 6",
+		},
+		{
+			name: "longer line",
+			text: "+---+\n|   |  |\n+---+\n",
+			want: "line 2, column 6: line is longer than the first one (5 characters)",
+		},
+		{
+			name: "missing corner",
+			text: "+---+---+\n|       |\n+---+---|\n",
+			want: "line 3, column 9: expected '+', got '|'",
+		},
+		{
+			name: "broken horizontal wall",
+			text: "+---+---+\n|       |\n+---+ - +\n",
+			want: `line 3, column 6: expected "---" or "   ", got " - "`,
+		},
+		{
+			name: "bad vertical wall",
+			text: "+---+---+\n|   -   |\n+---+---+\n",
+			want: "line 2, column 5: expected '|' or a space, got '-'",
+		},
+		{
+			name: "unknown marker",
+			text: "\n+---+---+\n|   | X |\n+---+---+\n",
+			want: "line 3, column 7: unknown marker 'X'",
+		},
+		{
+			name: "two cell types",
+			text: "+---+\n|DF |\n+---+\n",
+			want: "line 2, column 3: cell (0,0) already has the cell type marker 'D'",
+		},
+		{
+			name: "two starts",
+			text: "+---+---+\n| S   S |\n+---+---+\n",
+			want: "line 2, column 7: start is already marked at (0,0)",
+		},
+		{
+			name: "two exits",
+			text: "+---+\n|E E|\n+---+\n",
+			want: "line 2, column 4: exit is already marked at (0,0)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.text)
+			if err == nil {
+				t.Fatalf("Parse succeeded, want error %q", tt.want)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("Parse error = %q, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+	m, err := Parse(handDrawn["single cell"])
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	outside := mazeanalysis.Point{X: 1, Y: 0}
+	m.Exit = &outside
+	if _, err := Format(m); err == nil {
+		t.Error("Format succeeded with the exit outside the layout")
+	}
+
+	m.Exit = m.Start
+	m.Collectibles = []mazeanalysis.Point{*m.Start, *m.Start}
+	if _, err := Format(m); err == nil {
+		t.Error("Format succeeded with four markers in a cell")
+	}
+}
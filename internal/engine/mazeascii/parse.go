@@ -0,0 +1,173 @@
+package mazeascii
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
+	"github.com/juanancid/maze-adventure/internal/engine/mazeanalysis"
+)
+
+// ParseLayout reads a layout, the entity markers are checked but dropped
+func ParseLayout(text string) (mazelayout.Layout, error) {
+	m, err := Parse(text)
+	if err != nil {
+		return mazelayout.Layout{}, err
+	}
+	return m.Layout, nil
+}
+
+// Parse reads a map drawn in the text format. Blank lines around the drawing and
+// trailing spaces are ignored, errors report the line and column they refer to.
+func Parse(text string) (Map, error) {
+	lines, firstLine := trimLines(text)
+	if len(lines) < 3 || len(lines)%2 == 0 {
+		return Map{}, fmt.Errorf("a maze needs an odd number of lines, at least 3, got %d", len(lines))
+	}
+
+	width := len(lines[0])
+	if width < cellWidth+2 || (width-1)%(cellWidth+1) != 0 {
+		return Map{}, fmt.Errorf("line %d: a maze line needs 4 characters per cell plus one, got %d", firstLine, width)
+	}
+
+	cols := (width - 1) / (cellWidth + 1)
+	rows := (len(lines) - 1) / 2
+
+	errorAt := func(line, column int, format string, args ...any) error {
+		return fmt.Errorf("line %d, column %d: %s", firstLine+line, column+1, fmt.Sprintf(format, args...))
+	}
+	walls := make([][][4]bool, rows)
+	cellTypes := make([][]rune, rows)
+	var m Map
+
+	for i, line := range lines {
+		if len(line) > width {
+			return Map{}, errorAt(i, width, "line is longer than the first one (%d characters)", width)
+		}
+		lines[i] = line + strings.Repeat(" ", width-len(line))
+	}
+
+	for y := 0; y <= rows; y++ {
+		line := 2 * y
+		for x := 0; x < cols; x++ {
+			column := x * (cellWidth + 1)
+			if lines[line][column] != corner {
+				return Map{}, errorAt(line, column, "expected %q, got %q", corner, lines[line][column])
+			}
+
+			segment := lines[line][column+1 : column+1+cellWidth]
+			if segment != horizontalWall && segment != openPassage {
+				return Map{}, errorAt(line, column+1, "expected %q or %q, got %q", horizontalWall, openPassage, segment)
+			}
+			wall := segment == horizontalWall
+			if y > 0 {
+				walls[y-1][x][2] = wall
+			}
+			if y < rows {
+				if x == 0 {
+					walls[y] = make([][4]bool, cols)
+					cellTypes[y] = make([]rune, cols)
+				}
+				walls[y][x][0] = wall
+			}
+		}
+		if lines[line][width-1] != corner {
+			return Map{}, errorAt(line, width-1, "expected %q, got %q", corner, lines[line][width-1])
+		}
+	}
+
+	for y := 0; y < rows; y++ {
+		line := 2*y + 1
+		for x := 0; x <= cols; x++ {
+			column := x * (cellWidth + 1)
+			char := lines[line][column]
+			if char != verticalWall && char != ' ' {
+				return Map{}, errorAt(line, column, "expected %q or a space, got %q", verticalWall, char)
+			}
+			wall := char == verticalWall
+			if x > 0 {
+				walls[y][x-1][1] = wall
+			}
+			if x < cols {
+				walls[y][x][3] = wall
+			}
+		}
+
+		for x := 0; x < cols; x++ {
+			column := x*(cellWidth+1) + 1
+			cell := mazeanalysis.Point{X: x, Y: y}
+			for offset, char := range lines[line][column : column+cellWidth] {
+				if err := markCell(&m, cellTypes, cell, char); err != nil {
+					return Map{}, errorAt(line, column+offset, "%v", err)
+				}
+			}
+		}
+	}
+
+	grid := make([][]mazelayout.Cell, rows)
+	for y := range grid {
+		grid[y] = make([]mazelayout.Cell, cols)
+		for x := range grid[y] {
+			switch cellTypes[y][x] {
+			case MarkerDeadly:
+				grid[y][x] = mazelayout.NewDeadlyCell(walls[y][x])
+			case MarkerFreezing:
+				grid[y][x] = mazelayout.NewFreezingCell(walls[y][x])
+			default:
+				grid[y][x] = mazelayout.NewRegularCell(walls[y][x])
+			}
+		}
+	}
+	m.Layout = mazelayout.NewLayout(cols, rows, grid)
+
+	return m, nil
+}
+
+// markCell records a marker found inside a cell
+func markCell(m *Map, cellTypes [][]rune, cell mazeanalysis.Point, char rune) error {
+	switch char {
+	case ' ':
+	case MarkerDeadly, MarkerFreezing:
+		if cellTypes[cell.Y][cell.X] != 0 {
+			return fmt.Errorf("cell (%d,%d) already has the cell type marker %q", cell.X, cell.Y, cellTypes[cell.Y][cell.X])
+		}
+		cellTypes[cell.Y][cell.X] = char
+	case MarkerStart:
+		if m.Start != nil {
+			return fmt.Errorf("start is already marked at (%d,%d)", m.Start.X, m.Start.Y)
+		}
+		m.Start = &cell
+	case MarkerExit:
+		if m.Exit != nil {
+			return fmt.Errorf("exit is already marked at (%d,%d)", m.Exit.X, m.Exit.Y)
+		}
+		m.Exit = &cell
+	case MarkerCollectible:
+		m.Collectibles = append(m.Collectibles, cell)
+	case MarkerPatroller:
+		m.Patrollers = append(m.Patrollers, cell)
+	default:
+		return fmt.Errorf("unknown marker %q", char)
+	}
+	return nil
+}
+
+// trimLines splits the text into lines without trailing spaces nor surrounding blank lines.
+// It also returns the 1-based number of the first kept line.
+func trimLines(text string) ([]string, int) {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " \t")
+	}
+
+	first := 0
+	for first < len(lines) && lines[first] == "" {
+		first++
+	}
+	last := len(lines)
+	for last > first && lines[last-1] == "" {
+		last--
+	}
+
+	return lines[first:last], first + 1
+}
@@ -0,0 +1,38 @@
+package mazebuilder
+
+import "math/rand"
+
+// recursiveBacktracker carves the maze with a randomized depth-first search.
+// It produces long winding corridors with very few junctions.
+type recursiveBacktracker struct{}
+
+func (recursiveBacktracker) Generate(grid builderGrid, r *rand.Rand) {
+	start := grid[0][0]
+	start.visited = true
+	stack := []*builderCell{start}
+
+	for len(stack) > 0 {
+		// Peek at the top of the stack.
+		current := stack[len(stack)-1]
+
+		// Collect all unvisited neighbors.
+		neighbors, directions := grid.unvisitedNeighbors(current)
+
+		if len(neighbors) > 0 {
+			// Randomly choose one unvisited neighbor.
+			idx := r.Intn(len(neighbors))
+			neighbor := neighbors[idx]
+			dir := directions[idx]
+
+			// Carve the path between current and neighbor.
+			removeWall(current, neighbor, dir)
+
+			// Mark neighbor as visited and push it onto the stack.
+			neighbor.visited = true
+			stack = append(stack, neighbor)
+		} else {
+			// Backtrack if no unvisited neighbors.
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
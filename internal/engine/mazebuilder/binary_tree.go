@@ -0,0 +1,30 @@
+package mazebuilder
+
+import "math/rand"
+
+// binaryTree carves the maze by opening either the top or the right wall of every cell.
+// It is very fast but strongly biased: the top row and the right column are open corridors.
+type binaryTree struct{}
+
+func (binaryTree) Generate(grid builderGrid, r *rand.Rand) {
+	for y := 0; y < grid.rows(); y++ {
+		for x := 0; x < grid.cols(); x++ {
+			cell := grid[y][x]
+			cell.visited = true
+
+			var directions []int
+			if y > 0 {
+				directions = append(directions, 0) // Top
+			}
+			if x < grid.cols()-1 {
+				directions = append(directions, 1) // Right
+			}
+			if len(directions) == 0 {
+				continue // Top-right corner
+			}
+
+			dir := directions[r.Intn(len(directions))]
+			removeWall(cell, grid.neighbor(cell, dir), dir)
+		}
+	}
+}
@@ -5,17 +5,24 @@ import (
 	"math/rand"
 	"time"
 
-	"github.com/juanancid/maze-adventure/internal/core/components"
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
+	"github.com/juanancid/maze-adventure/internal/engine/mazeanalysis"
 )
 
 // BuilderConfig holds the configuration for maze generation
 type BuilderConfig struct {
-	Width                 int     // Width of the maze in cells
-	Height                int     // Height of the maze in cells
-	DeadlyCells           int     // Number of deadly cells to place
-	FreezingCells         int     // Number of freezing cells to place
-	ExtraConnectionChance float64 // Probability (0.0-1.0) of adding extra connections
-	Seed                  int64   // Optional seed for random generation
+	Width                 int       // Width of the maze in cells
+	Height                int       // Height of the maze in cells
+	Algorithm             Algorithm // Algorithm used to carve the maze passages
+	DeadlyCells           int       // Number of deadly cells to place
+	FreezingCells         int       // Number of freezing cells to place
+	ExtraConnectionChance float64   // Probability (0.0-1.0) of adding extra connections
+	Seed                  int64     // Seed for every random decision, the same seed builds the same maze
+
+	Start        mazeanalysis.Point   // Cell where the player starts
+	Exit         mazeanalysis.Point   // Cell where the exit is placed
+	Collectibles []mazeanalysis.Point // Cells where collectibles will be placed
+	Placement    PlacementConfig      // Constraints for placing the special cells
 }
 
 // NewBuilderConfig creates a new builder configuration with default values
@@ -23,10 +30,13 @@ func NewBuilderConfig(width, height int) *BuilderConfig {
 	return &BuilderConfig{
 		Width:                 width,
 		Height:                height,
+		Algorithm:             DefaultAlgorithm,
 		DeadlyCells:           0,   // Default to 0 -> can be overridden
 		FreezingCells:         0,   // Default to 0 -> can be overridden
 		ExtraConnectionChance: 0.0, // Default to 0% chance -> can be overridden
 		Seed:                  time.Now().UnixNano(),
+		Start:                 mazeanalysis.Point{X: 0, Y: 0},
+		Exit:                  mazeanalysis.Point{X: width - 1, Y: height - 1},
 	}
 }
 
@@ -49,51 +59,48 @@ func (b *BuilderConfig) Validate() error {
 		return fmt.Errorf("extra connection chance must be between 0.0 and 1.0, got: %f", b.ExtraConnectionChance)
 	}
 
-	return nil
-}
+	if !b.Algorithm.IsValid() {
+		return fmt.Errorf("unknown maze generation algorithm: %q", b.Algorithm)
+	}
 
-// Build creates a new maze with the specified configuration
-func Build(config *BuilderConfig) (components.Layout, error) {
-	if err := config.Validate(); err != nil {
-		return components.Layout{}, fmt.Errorf("invalid builder config: %w", err)
+	if !b.inBounds(b.Start) || !b.inBounds(b.Exit) {
+		return fmt.Errorf("start %v and exit %v must be inside the maze", b.Start, b.Exit)
 	}
 
-	layout := newMazeLayout(config.Width, config.Height, config.ExtraConnectionChance)
-	r := rand.New(rand.NewSource(config.Seed))
-	placeSpecialCells(layout, config, r)
+	for _, collectible := range b.Collectibles {
+		if !b.inBounds(collectible) {
+			return fmt.Errorf("collectible %v must be inside the maze", collectible)
+		}
+	}
 
-	return layout, nil
+	if err := b.Placement.Validate(); err != nil {
+		return fmt.Errorf("invalid placement config: %w", err)
+	}
+
+	return nil
 }
 
-// placeSpecialCells randomly places special cells in the maze
-func placeSpecialCells(layout components.Layout, config *BuilderConfig, r *rand.Rand) {
-	// Create a list of all possible positions
-	positions := make([]struct{ x, y int }, 0, layout.Cols()*layout.Rows())
-	for y := 0; y < layout.Rows(); y++ {
-		for x := 0; x < layout.Cols(); x++ {
-			positions = append(positions, struct{ x, y int }{x, y})
-		}
+func (b *BuilderConfig) inBounds(p mazeanalysis.Point) bool {
+	return inBounds(p.X, p.Y, b.Width, b.Height)
+}
+
+// Build creates a new maze with the specified configuration
+func Build(config *BuilderConfig) (mazelayout.Layout, error) {
+	if err := config.Validate(); err != nil {
+		return mazelayout.Layout{}, fmt.Errorf("invalid builder config: %w", err)
 	}
 
-	// Shuffle positions
-	r.Shuffle(len(positions), func(i, j int) {
-		positions[i], positions[j] = positions[j], positions[i]
-	})
-
-	// Place deadly cells
-	for i := 0; i < config.DeadlyCells && i < len(positions); i++ {
-		pos := positions[i]
-		cell := layout.GetCell(pos.x, pos.y)
-		deadlyCell := components.NewDeadlyCell(cell.GetWalls())
-		layout.SetCell(pos.x, pos.y, deadlyCell)
+	generator, err := newGenerator(config.Algorithm)
+	if err != nil {
+		return mazelayout.Layout{}, err
 	}
 
-	// Place freezing cells
-	startIdx := config.DeadlyCells
-	for i := 0; i < config.FreezingCells && startIdx+i < len(positions); i++ {
-		pos := positions[startIdx+i]
-		cell := layout.GetCell(pos.x, pos.y)
-		freezingCell := components.NewFreezingCell(cell.GetWalls())
-		layout.SetCell(pos.x, pos.y, freezingCell)
+	// A single random source drives every step so that the same seed always builds the same maze
+	r := rand.New(rand.NewSource(config.Seed))
+	layout := newMazeLayout(config.Width, config.Height, generator, config.ExtraConnectionChance, r)
+	if err := placeSpecialCells(layout, config, r); err != nil {
+		return mazelayout.Layout{}, err
 	}
+
+	return layout, nil
 }
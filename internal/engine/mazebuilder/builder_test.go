@@ -0,0 +1,94 @@
+package mazebuilder
+
+import (
+	"testing"
+
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
+	"github.com/juanancid/maze-adventure/internal/engine/mazeanalysis"
+)
+
+func TestEveryAlgorithmBuildsAPerfectMaze(t *testing.T) {
+	sizes := []struct{ width, height int }{{1, 1}, {1, 6}, {7, 1}, {9, 7}, {16, 12}}
+
+	for _, algorithm := range Algorithms() {
+		t.Run(string(algorithm), func(t *testing.T) {
+			for _, size := range sizes {
+				for seed := int64(1); seed <= 5; seed++ {
+					config := NewBuilderConfig(size.width, size.height)
+					config.Algorithm = algorithm
+					config.Seed = seed
+
+					layout, err := Build(config)
+					if err != nil {
+						t.Fatalf("%dx%d seed %d: Build: %v", size.width, size.height, seed, err)
+					}
+					checkPerfectMaze(t, layout, size.width, size.height, seed)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildIsDeterministicForASeed(t *testing.T) {
+	for _, algorithm := range Algorithms() {
+		t.Run(string(algorithm), func(t *testing.T) {
+			build := func() mazelayout.Layout {
+				config := NewBuilderConfig(12, 9)
+				config.Algorithm = algorithm
+				config.Seed = 7
+				config.DeadlyCells = 4
+				config.FreezingCells = 3
+				config.ExtraConnectionChance = 0.2
+
+				layout, err := Build(config)
+				if err != nil {
+					t.Fatalf("Build: %v", err)
+				}
+				return layout
+			}
+
+			first, second := build(), build()
+			for y := 0; y < first.Rows(); y++ {
+				for x := 0; x < first.Cols(); x++ {
+					if first.GetCell(x, y) != second.GetCell(x, y) {
+						t.Fatalf("cell (%d,%d) differs between two builds with the same seed", x, y)
+					}
+				}
+			}
+		})
+	}
+}
+
+// checkPerfectMaze reports whether the layout is a spanning tree of its grid: closed
+// borders, walls that agree between neighbours, every cell reachable and cells-1 passages.
+func checkPerfectMaze(t *testing.T, layout mazelayout.Layout, width, height int, seed int64) {
+	t.Helper()
+
+	if layout.Cols() != width || layout.Rows() != height {
+		t.Fatalf("%dx%d seed %d: layout is %dx%d", width, height, seed, layout.Cols(), layout.Rows())
+	}
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			cell := layout.GetCell(x, y)
+			if (y == 0 && !cell.HasTopWall()) || (x == width-1 && !cell.HasRightWall()) ||
+				(y == height-1 && !cell.HasBottomWall()) || (x == 0 && !cell.HasLeftWall()) {
+				t.Errorf("%dx%d seed %d: cell (%d,%d) is open to the outside", width, height, seed, x, y)
+			}
+			if x < width-1 && cell.HasRightWall() != layout.GetCellRight(x, y).HasLeftWall() {
+				t.Errorf("%dx%d seed %d: cells (%d,%d) and (%d,%d) disagree on their wall", width, height, seed, x, y, x+1, y)
+			}
+			if y < height-1 && cell.HasBottomWall() != layout.GetCellBelow(x, y).HasTopWall() {
+				t.Errorf("%dx%d seed %d: cells (%d,%d) and (%d,%d) disagree on their wall", width, height, seed, x, y, x, y+1)
+			}
+		}
+	}
+
+	cells := width * height
+	if passages := mazeanalysis.CountTopology(layout).Passages; passages != cells-1 {
+		t.Errorf("%dx%d seed %d: %d passages, want %d", width, height, seed, passages, cells-1)
+	}
+	if reachable := mazeanalysis.Distances(layout, mazeanalysis.Point{}).Reachable(); reachable != cells {
+		t.Errorf("%dx%d seed %d: %d of %d cells reachable", width, height, seed, reachable, cells)
+	}
+}
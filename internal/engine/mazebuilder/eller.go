@@ -0,0 +1,79 @@
+package mazebuilder
+
+import "math/rand"
+
+// eller carves the maze one row at a time with Eller's algorithm.
+// Only the set membership of the current row is kept, and horizontal runs tend to be long.
+type eller struct{}
+
+// ellerJoinChance is the probability of joining two horizontally adjacent cells of different sets
+const ellerJoinChance = 0.5
+
+func (eller) Generate(grid builderGrid, r *rand.Rand) {
+	cols, rows := grid.cols(), grid.rows()
+
+	sets := make([]int, cols)
+	nextSet := 1
+
+	for y := 0; y < rows; y++ {
+		lastRow := y == rows-1
+
+		// Cells that were not carved into from above start in their own set.
+		for x := 0; x < cols; x++ {
+			if sets[x] == 0 {
+				sets[x] = nextSet
+				nextSet++
+			}
+			grid[y][x].visited = true
+		}
+
+		// Randomly join adjacent cells that belong to different sets. The last row joins all of them.
+		for x := 0; x < cols-1; x++ {
+			if sets[x] == sets[x+1] {
+				continue
+			}
+			if lastRow || r.Float64() < ellerJoinChance {
+				removeWall(grid[y][x], grid[y][x+1], 1)
+				mergeEllerSets(sets, sets[x+1], sets[x])
+			}
+		}
+
+		if lastRow {
+			break
+		}
+
+		// Every set carves at least one passage down into the next row.
+		members := make(map[int][]int)
+		var order []int
+		for x := 0; x < cols; x++ {
+			if _, ok := members[sets[x]]; !ok {
+				order = append(order, sets[x])
+			}
+			members[sets[x]] = append(members[sets[x]], x)
+		}
+
+		nextSets := make([]int, cols)
+		for _, set := range order {
+			columns := members[set]
+			r.Shuffle(len(columns), func(i, j int) {
+				columns[i], columns[j] = columns[j], columns[i]
+			})
+
+			passages := 1 + r.Intn(len(columns))
+			for _, x := range columns[:passages] {
+				removeWall(grid[y][x], grid[y+1][x], 2)
+				nextSets[x] = set
+			}
+		}
+		sets = nextSets
+	}
+}
+
+// mergeEllerSets replaces every occurrence of one set with another in the current row
+func mergeEllerSets(sets []int, from, to int) {
+	for i := range sets {
+		if sets[i] == from {
+			sets[i] = to
+		}
+	}
+}
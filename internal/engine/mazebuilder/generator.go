@@ -1,22 +1,99 @@
 package mazebuilder
 
 import (
+	"fmt"
 	"math/rand"
-	"time"
 
-	"github.com/juanancid/maze-adventure/internal/core/components"
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
 )
 
-func newMazeLayout(cols, rows int, extraConnectionChance float64) components.Layout {
+// Algorithm identifies the maze generation algorithm used to carve the passages
+type Algorithm string
+
+const (
+	AlgorithmRecursiveBacktracker Algorithm = "recursive-backtracker" // Long winding corridors, few junctions
+	AlgorithmPrim                 Algorithm = "prim"                  // Short branches radiating from many junctions
+	AlgorithmKruskal              Algorithm = "kruskal"               // Uniformly scattered junctions and short dead ends
+	AlgorithmWilson               Algorithm = "wilson"                // Unbiased uniform spanning tree
+	AlgorithmEller                Algorithm = "eller"                 // Row-by-row generation with horizontal streaks
+	AlgorithmHuntAndKill          Algorithm = "hunt-and-kill"         // Long corridors similar to the backtracker
+	AlgorithmBinaryTree           Algorithm = "binary-tree"           // Strong diagonal bias with open top and right edges
+	AlgorithmGrowingTree          Algorithm = "growing-tree"          // Mix between backtracker and Prim textures
+)
+
+// DefaultAlgorithm is used when no algorithm is configured
+const DefaultAlgorithm = AlgorithmRecursiveBacktracker
+
+// generator carves passages into a grid whose cells start with all their walls up.
+// Every generator produces a perfect maze: all cells are reachable and there are no loops.
+// Generators are picked by Algorithm, a new one is plugged in by adding it to generators.
+type generator interface {
+	Generate(grid builderGrid, r *rand.Rand)
+}
+
+var generators = map[Algorithm]func() generator{
+	AlgorithmRecursiveBacktracker: func() generator { return recursiveBacktracker{} },
+	AlgorithmPrim:                 func() generator { return prim{} },
+	AlgorithmKruskal:              func() generator { return kruskal{} },
+	AlgorithmWilson:               func() generator { return wilson{} },
+	AlgorithmEller:                func() generator { return eller{} },
+	AlgorithmHuntAndKill:          func() generator { return huntAndKill{} },
+	AlgorithmBinaryTree:           func() generator { return binaryTree{} },
+	AlgorithmGrowingTree:          func() generator { return newGrowingTree() },
+}
+
+// Algorithms returns all the supported algorithms in a stable order
+func Algorithms() []Algorithm {
+	return []Algorithm{
+		AlgorithmRecursiveBacktracker,
+		AlgorithmPrim,
+		AlgorithmKruskal,
+		AlgorithmWilson,
+		AlgorithmEller,
+		AlgorithmHuntAndKill,
+		AlgorithmBinaryTree,
+		AlgorithmGrowingTree,
+	}
+}
+
+// IsValid returns true if the algorithm is supported. The empty algorithm is valid and means DefaultAlgorithm.
+func (a Algorithm) IsValid() bool {
+	if a == "" {
+		return true
+	}
+	_, ok := generators[a]
+	return ok
+}
+
+// newGenerator returns the generator for the given algorithm
+func newGenerator(algorithm Algorithm) (generator, error) {
+	if algorithm == "" {
+		algorithm = DefaultAlgorithm
+	}
+
+	create, ok := generators[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("unknown maze generation algorithm: %q", algorithm)
+	}
+
+	return create(), nil
+}
+
+func newMazeLayout(cols, rows int, gen generator, extraConnectionChance float64, r *rand.Rand) mazelayout.Layout {
 	bGrid := initializeBuilderGrid(cols, rows)
 
-	startCol, startRow := 0, 0
-	carveMazePaths(startCol, startRow, cols, rows, bGrid)
-	addExtraConnections(bGrid, extraConnectionChance)
+	gen.Generate(bGrid, r)
+	addExtraConnections(bGrid, extraConnectionChance, r)
 
 	return convertBuilderGridToLayout(bGrid, cols, rows)
 }
 
+// Direction offsets indexed by wall: 0=top, 1=right, 2=bottom, 3=left
+var (
+	dx = [4]int{0, 1, 0, -1}
+	dy = [4]int{-1, 0, 1, 0}
+)
+
 type builderCell struct {
 	x, y    int
 	visited bool
@@ -44,49 +121,56 @@ func initializeBuilderGrid(cols, rows int) builderGrid {
 	return grid
 }
 
-func carveMazePaths(startCol, startRow int, cols, rows int, grid builderGrid) {
-	dx := [4]int{0, 1, 0, -1}
-	dy := [4]int{-1, 0, 1, 0}
-
-	stack := []*builderCell{grid[startRow][startCol]}
+func (g builderGrid) rows() int {
+	return len(g)
+}
 
-	start := grid[startRow][startCol]
-	start.visited = true
-	stack = append(stack, start)
+func (g builderGrid) cols() int {
+	if len(g) == 0 {
+		return 0
+	}
+	return len(g[0])
+}
 
-	for len(stack) > 0 {
-		// Peek at the top of the stack.
-		current := stack[len(stack)-1]
+// neighbor returns the cell next to the given one in the given direction, or nil if it is out of bounds
+func (g builderGrid) neighbor(cell *builderCell, dir int) *builderCell {
+	nx := cell.x + dx[dir]
+	ny := cell.y + dy[dir]
+	if !inBounds(nx, ny, g.cols(), g.rows()) {
+		return nil
+	}
+	return g[ny][nx]
+}
 
-		// Collect all unvisited neighbors.
-		var neighbors []*builderCell
-		var directions []int
-		for dir := 0; dir < 4; dir++ {
-			nx := current.x + dx[dir]
-			ny := current.y + dy[dir]
-			if inBounds(nx, ny, cols, rows) && !grid[ny][nx].visited {
-				neighbors = append(neighbors, grid[ny][nx])
-				directions = append(directions, dir)
-			}
+// unvisitedNeighbors returns the in-bounds unvisited neighbors of a cell along with their directions
+func (g builderGrid) unvisitedNeighbors(cell *builderCell) ([]*builderCell, []int) {
+	var neighbors []*builderCell
+	var directions []int
+	for dir := 0; dir < 4; dir++ {
+		if n := g.neighbor(cell, dir); n != nil && !n.visited {
+			neighbors = append(neighbors, n)
+			directions = append(directions, dir)
 		}
+	}
+	return neighbors, directions
+}
 
-		if len(neighbors) > 0 {
-			// Randomly choose one unvisited neighbor.
-			idx := rand.Intn(len(neighbors))
-			neighbor := neighbors[idx]
-			dir := directions[idx]
-
-			// Carve the path between current and neighbor.
-			removeWall(current, neighbor, dir)
-
-			// Mark neighbor as visited and push it onto the stack.
-			neighbor.visited = true
-			stack = append(stack, neighbor)
-		} else {
-			// Backtrack if no unvisited neighbors.
-			stack = stack[:len(stack)-1]
+// visitedNeighbors returns the in-bounds visited neighbors of a cell along with their directions
+func (g builderGrid) visitedNeighbors(cell *builderCell) ([]*builderCell, []int) {
+	var neighbors []*builderCell
+	var directions []int
+	for dir := 0; dir < 4; dir++ {
+		if n := g.neighbor(cell, dir); n != nil && n.visited {
+			neighbors = append(neighbors, n)
+			directions = append(directions, dir)
 		}
 	}
+	return neighbors, directions
+}
+
+// randomCell returns a uniformly chosen cell of the grid
+func (g builderGrid) randomCell(r *rand.Rand) *builderCell {
+	return g[r.Intn(g.rows())][r.Intn(g.cols())]
 }
 
 func inBounds(x, y, width, height int) bool {
@@ -98,13 +182,9 @@ func removeWall(current, neighbor *builderCell, dir int) {
 	neighbor.walls[(dir+2)%4] = false // Remove the opposite wall in neighbor.
 }
 
-func addExtraConnections(grid builderGrid, chance float64) {
-	dx := [4]int{0, 1, 0, -1}
-	dy := [4]int{-1, 0, 1, 0}
-
+func addExtraConnections(grid builderGrid, chance float64, r *rand.Rand) {
 	rows := len(grid)
 	cols := len(grid[0])
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
 
 	for y := 0; y < rows; y++ {
 		for x := 0; x < cols; x++ {
@@ -145,14 +225,14 @@ func wallsBetween(a, b *builderCell) bool {
 	return false
 }
 
-func convertBuilderGridToLayout(grid builderGrid, cols, rows int) components.Layout {
-	finalGrid := make([][]components.Cell, rows)
+func convertBuilderGridToLayout(grid builderGrid, cols, rows int) mazelayout.Layout {
+	finalGrid := make([][]mazelayout.Cell, rows)
 	for y := range grid {
-		finalGrid[y] = make([]components.Cell, cols)
+		finalGrid[y] = make([]mazelayout.Cell, cols)
 		for x := range grid[y] {
-			finalGrid[y][x] = components.NewRegularCell(grid[y][x].walls)
+			finalGrid[y][x] = mazelayout.NewRegularCell(grid[y][x].walls)
 		}
 	}
 
-	return components.NewLayout(cols, rows, finalGrid)
+	return mazelayout.NewLayout(cols, rows, finalGrid)
 }
@@ -0,0 +1,43 @@
+package mazebuilder
+
+import "math/rand"
+
+// growingTree carves the maze by growing a list of active cells.
+// newestBias is the probability (0.0-1.0) of extending the newest active cell instead of a random one:
+// 1.0 behaves like the recursive backtracker and 0.0 like Prim's algorithm.
+type growingTree struct {
+	newestBias float64
+}
+
+// newGrowingTree creates a growing tree generator with an even mix of both textures
+func newGrowingTree() growingTree {
+	return growingTree{
+		newestBias: 0.5,
+	}
+}
+
+func (g growingTree) Generate(grid builderGrid, r *rand.Rand) {
+	start := grid.randomCell(r)
+	start.visited = true
+	active := []*builderCell{start}
+
+	for len(active) > 0 {
+		idx := len(active) - 1
+		if r.Float64() >= g.newestBias {
+			idx = r.Intn(len(active))
+		}
+		current := active[idx]
+
+		neighbors, directions := grid.unvisitedNeighbors(current)
+		if len(neighbors) == 0 {
+			// The cell is exhausted, remove it while keeping the insertion order.
+			active = append(active[:idx], active[idx+1:]...)
+			continue
+		}
+
+		n := r.Intn(len(neighbors))
+		removeWall(current, neighbors[n], directions[n])
+		neighbors[n].visited = true
+		active = append(active, neighbors[n])
+	}
+}
@@ -0,0 +1,50 @@
+package mazebuilder
+
+import "math/rand"
+
+// huntAndKill carves the maze with random walks. When a walk gets stuck, the grid is
+// scanned for an unvisited cell next to the maze and a new walk starts from there.
+type huntAndKill struct{}
+
+func (huntAndKill) Generate(grid builderGrid, r *rand.Rand) {
+	current := grid.randomCell(r)
+	current.visited = true
+
+	for current != nil {
+		neighbors, directions := grid.unvisitedNeighbors(current)
+		if len(neighbors) > 0 {
+			// Kill: walk randomly into an unvisited neighbor.
+			idx := r.Intn(len(neighbors))
+			removeWall(current, neighbors[idx], directions[idx])
+			neighbors[idx].visited = true
+			current = neighbors[idx]
+			continue
+		}
+
+		current = hunt(grid, r)
+	}
+}
+
+// hunt finds the first unvisited cell adjacent to the maze, connects it, and returns it.
+// It returns nil when every cell has been visited.
+func hunt(grid builderGrid, r *rand.Rand) *builderCell {
+	for y := 0; y < grid.rows(); y++ {
+		for x := 0; x < grid.cols(); x++ {
+			cell := grid[y][x]
+			if cell.visited {
+				continue
+			}
+
+			neighbors, directions := grid.visitedNeighbors(cell)
+			if len(neighbors) == 0 {
+				continue
+			}
+
+			idx := r.Intn(len(neighbors))
+			removeWall(cell, neighbors[idx], directions[idx])
+			cell.visited = true
+			return cell
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package mazebuilder
+
+import "math/rand"
+
+// kruskal carves the maze with a randomized version of Kruskal's algorithm.
+// Walls are removed in random order whenever they separate two disjoint regions,
+// which scatters junctions and short dead ends uniformly across the maze.
+type kruskal struct{}
+
+func (kruskal) Generate(grid builderGrid, r *rand.Rand) {
+	cols, rows := grid.cols(), grid.rows()
+
+	// Only right and bottom walls are listed so that every wall appears once.
+	walls := make([]frontierWall, 0, 2*cols*rows)
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			if x < cols-1 {
+				walls = append(walls, frontierWall{cell: grid[y][x], dir: 1})
+			}
+			if y < rows-1 {
+				walls = append(walls, frontierWall{cell: grid[y][x], dir: 2})
+			}
+		}
+	}
+
+	r.Shuffle(len(walls), func(i, j int) {
+		walls[i], walls[j] = walls[j], walls[i]
+	})
+
+	sets := newDisjointSets(cols * rows)
+	for _, wall := range walls {
+		neighbor := grid.neighbor(wall.cell, wall.dir)
+		if sets.union(wall.cell.y*cols+wall.cell.x, neighbor.y*cols+neighbor.x) {
+			removeWall(wall.cell, neighbor, wall.dir)
+		}
+	}
+
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			grid[y][x].visited = true
+		}
+	}
+}
+
+// disjointSets is a union-find structure over cell indexes
+type disjointSets struct {
+	parent []int
+	rank   []int
+}
+
+func newDisjointSets(size int) *disjointSets {
+	parent := make([]int, size)
+	for i := range parent {
+		parent[i] = i
+	}
+	return &disjointSets{
+		parent: parent,
+		rank:   make([]int, size),
+	}
+}
+
+func (d *disjointSets) find(i int) int {
+	for d.parent[i] != i {
+		d.parent[i] = d.parent[d.parent[i]]
+		i = d.parent[i]
+	}
+	return i
+}
+
+// union merges the sets containing a and b. It returns false if they were already the same set.
+func (d *disjointSets) union(a, b int) bool {
+	rootA, rootB := d.find(a), d.find(b)
+	if rootA == rootB {
+		return false
+	}
+
+	switch {
+	case d.rank[rootA] < d.rank[rootB]:
+		d.parent[rootA] = rootB
+	case d.rank[rootA] > d.rank[rootB]:
+		d.parent[rootB] = rootA
+	default:
+		d.parent[rootB] = rootA
+		d.rank[rootA]++
+	}
+	return true
+}
@@ -0,0 +1,207 @@
+package mazebuilder
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
+	"github.com/juanancid/maze-adventure/internal/engine/mazeanalysis"
+)
+
+// ErrPlacementUnsatisfiable is returned when the special cells cannot be placed under the configured constraints
+var ErrPlacementUnsatisfiable = errors.New("special cell placement constraints cannot be satisfied")
+
+// PlacementStrategy decides which cells are preferred for special cells
+type PlacementStrategy string
+
+const (
+	PlacementUniform      PlacementStrategy = "uniform"       // Any allowed cell is equally likely
+	PlacementCriticalPath PlacementStrategy = "critical-path" // As close to the shortest start-exit path as possible
+	PlacementDeadEnds     PlacementStrategy = "dead-ends"     // Only in dead ends
+	PlacementClustered    PlacementStrategy = "clustered"     // Grouped around a few random spots
+)
+
+// SafePathMode decides which special cells must be avoidable on the way from start to exit
+type SafePathMode string
+
+const (
+	SafePathDeadlyFree SafePathMode = "deadly-free" // There is always a path without deadly cells
+	SafePathHazardFree SafePathMode = "hazard-free" // There is always a path without any special cell
+	SafePathNone       SafePathMode = "none"        // No guarantee
+)
+
+// PlacementConfig holds the constraints for placing special cells.
+// The zero value places cells uniformly while keeping a deadly-free path,
+// and never on the start, exit or collectible cells themselves.
+type PlacementConfig struct {
+	Strategy                   PlacementStrategy `json:"strategy,omitempty" yaml:"strategy,omitempty"`                                     // Which cells are preferred, empty means PlacementUniform
+	SafePath                   SafePathMode      `json:"safePath,omitempty" yaml:"safePath,omitempty"`                                     // Which cells must be avoidable, empty means SafePathDeadlyFree
+	StartExclusionRadius       int               `json:"startExclusionRadius,omitempty" yaml:"startExclusionRadius,omitempty"`             // Steps around the start cell kept free of special cells
+	ExitExclusionRadius        int               `json:"exitExclusionRadius,omitempty" yaml:"exitExclusionRadius,omitempty"`               // Steps around the exit cell kept free of special cells
+	CollectibleExclusionRadius int               `json:"collectibleExclusionRadius,omitempty" yaml:"collectibleExclusionRadius,omitempty"` // Steps around every collectible kept free of special cells
+}
+
+// Validate ensures the placement configuration is valid
+func (p PlacementConfig) Validate() error {
+	switch p.Strategy {
+	case "", PlacementUniform, PlacementCriticalPath, PlacementDeadEnds, PlacementClustered:
+	default:
+		return fmt.Errorf("unknown placement strategy: %q", p.Strategy)
+	}
+
+	switch p.SafePath {
+	case "", SafePathDeadlyFree, SafePathHazardFree, SafePathNone:
+	default:
+		return fmt.Errorf("unknown safe path mode: %q", p.SafePath)
+	}
+
+	if p.StartExclusionRadius < 0 || p.ExitExclusionRadius < 0 || p.CollectibleExclusionRadius < 0 {
+		return fmt.Errorf("exclusion radii cannot be negative: start=%d, exit=%d, collectible=%d", p.StartExclusionRadius, p.ExitExclusionRadius, p.CollectibleExclusionRadius)
+	}
+
+	return nil
+}
+
+// clusterSize is the number of special cells grouped around each cluster center
+const clusterSize = 4
+
+// placeSpecialCells places the deadly and freezing cells honoring the placement constraints
+func placeSpecialCells(layout mazelayout.Layout, config *BuilderConfig, r *rand.Rand) error {
+	if config.DeadlyCells == 0 && config.FreezingCells == 0 {
+		return nil
+	}
+
+	candidates := orderCandidates(layout, config, allowedCells(layout, config), r)
+
+	blocks := blockingFunc(config.Placement.SafePath)
+	placed := make(map[mazeanalysis.Point]bool)
+
+	deadly := placeCells(layout, config, candidates, placed, blocks, config.DeadlyCells, mazelayout.NewDeadlyCell)
+	if deadly < config.DeadlyCells {
+		return fmt.Errorf("%w: placed %d of %d deadly cells", ErrPlacementUnsatisfiable, deadly, config.DeadlyCells)
+	}
+
+	freezing := placeCells(layout, config, candidates, placed, blocks, config.FreezingCells, mazelayout.NewFreezingCell)
+	if freezing < config.FreezingCells {
+		return fmt.Errorf("%w: placed %d of %d freezing cells", ErrPlacementUnsatisfiable, freezing, config.FreezingCells)
+	}
+
+	return nil
+}
+
+// allowedCells returns the cells outside every exclusion zone, in row-major order
+func allowedCells(layout mazelayout.Layout, config *BuilderConfig) []mazeanalysis.Point {
+	placement := config.Placement
+	fromStart := mazeanalysis.Distances(layout, config.Start)
+	fromExit := mazeanalysis.Distances(layout, config.Exit)
+	fromCollectibles := mazeanalysis.MultiSourceDistances(layout, config.Collectibles)
+
+	excluded := func(distances mazeanalysis.DistanceMap, p mazeanalysis.Point, radius int) bool {
+		d := distances.At(p)
+		return d != mazeanalysis.Unreachable && d <= radius
+	}
+
+	var allowed []mazeanalysis.Point
+	for y := 0; y < layout.Rows(); y++ {
+		for x := 0; x < layout.Cols(); x++ {
+			p := mazeanalysis.Point{X: x, Y: y}
+			if excluded(fromStart, p, placement.StartExclusionRadius) ||
+				excluded(fromExit, p, placement.ExitExclusionRadius) ||
+				excluded(fromCollectibles, p, placement.CollectibleExclusionRadius) {
+				continue
+			}
+			allowed = append(allowed, p)
+		}
+	}
+	return allowed
+}
+
+// orderCandidates shuffles the allowed cells and orders them by preference according to the strategy
+func orderCandidates(layout mazelayout.Layout, config *BuilderConfig, cells []mazeanalysis.Point, r *rand.Rand) []mazeanalysis.Point {
+	r.Shuffle(len(cells), func(i, j int) {
+		cells[i], cells[j] = cells[j], cells[i]
+	})
+
+	switch config.Placement.Strategy {
+	case PlacementCriticalPath:
+		path, _ := mazeanalysis.ShortestPath(layout, config.Start, config.Exit)
+		sortByDistance(cells, mazeanalysis.MultiSourceDistances(layout, path))
+
+	case PlacementDeadEnds:
+		deadEnds := cells[:0]
+		for _, p := range cells {
+			if mazeanalysis.Openings(layout, p) == 1 {
+				deadEnds = append(deadEnds, p)
+			}
+		}
+		cells = deadEnds
+
+	case PlacementClustered:
+		total := config.DeadlyCells + config.FreezingCells
+		clusters := min((total+clusterSize-1)/clusterSize, len(cells))
+		sortByDistance(cells, mazeanalysis.MultiSourceDistances(layout, cells[:clusters]))
+	}
+
+	return cells
+}
+
+// sortByDistance orders cells by ascending distance, keeping the shuffled order among ties
+func sortByDistance(cells []mazeanalysis.Point, distances mazeanalysis.DistanceMap) {
+	rank := func(p mazeanalysis.Point) int {
+		if d := distances.At(p); d != mazeanalysis.Unreachable {
+			return d
+		}
+		return len(cells) * len(cells)
+	}
+
+	sort.SliceStable(cells, func(i, j int) bool {
+		return rank(cells[i]) < rank(cells[j])
+	})
+}
+
+// blockingFunc returns which cells must be avoidable for the given safe path mode, or nil if none
+func blockingFunc(mode SafePathMode) func(cell mazelayout.Cell) bool {
+	switch mode {
+	case SafePathNone:
+		return nil
+	case SafePathHazardFree:
+		return mazeanalysis.IsHazard
+	default:
+		return mazelayout.Cell.IsDeadly
+	}
+}
+
+// placeCells turns up to count candidates into special cells, skipping any cell that would
+// break the safe path, and returns how many were placed
+func placeCells(layout mazelayout.Layout, config *BuilderConfig, candidates []mazeanalysis.Point, placed map[mazeanalysis.Point]bool, blocks func(mazelayout.Cell) bool, count int, newCell func(walls [4]bool) mazelayout.Cell) int {
+	passable := func(p mazeanalysis.Point) bool {
+		return !blocks(layout.GetCell(p.X, p.Y))
+	}
+
+	placedCount := 0
+	for _, p := range candidates {
+		if placedCount == count {
+			break
+		}
+		if placed[p] {
+			continue
+		}
+
+		previous := layout.GetCell(p.X, p.Y)
+		layout.SetCell(p.X, p.Y, newCell(previous.GetWalls()))
+
+		if blocks != nil {
+			if _, ok := mazeanalysis.ShortestPathAvoiding(layout, config.Start, config.Exit, passable); !ok {
+				layout.SetCell(p.X, p.Y, previous)
+				continue
+			}
+		}
+
+		placed[p] = true
+		placedCount++
+	}
+
+	return placedCount
+}
@@ -0,0 +1,177 @@
+package mazebuilder
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
+	"github.com/juanancid/maze-adventure/internal/engine/mazeanalysis"
+)
+
+var strategies = []PlacementStrategy{PlacementUniform, PlacementCriticalPath, PlacementDeadEnds, PlacementClustered}
+
+// specialCells returns the deadly and freezing cells of the layout
+func specialCells(layout mazelayout.Layout) (deadly, freezing []mazeanalysis.Point) {
+	for y := 0; y < layout.Rows(); y++ {
+		for x := 0; x < layout.Cols(); x++ {
+			switch cell := layout.GetCell(x, y); {
+			case cell.IsDeadly():
+				deadly = append(deadly, mazeanalysis.Point{X: x, Y: y})
+			case cell.IsFreezing():
+				freezing = append(freezing, mazeanalysis.Point{X: x, Y: y})
+			}
+		}
+	}
+	return deadly, freezing
+}
+
+func TestPlacementKeepsTheSafePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     SafePathMode
+		passable func(mazelayout.Cell) bool // Cells the safe path may cross, nil if there is no guarantee
+	}{
+		{name: "default", mode: "", passable: func(cell mazelayout.Cell) bool { return !cell.IsDeadly() }},
+		{name: "deadly-free", mode: SafePathDeadlyFree, passable: func(cell mazelayout.Cell) bool { return !cell.IsDeadly() }},
+		{name: "hazard-free", mode: SafePathHazardFree, passable: func(cell mazelayout.Cell) bool { return !mazeanalysis.IsHazard(cell) }},
+		{name: "none", mode: SafePathNone},
+	}
+
+	for _, tt := range tests {
+		for _, strategy := range strategies {
+			t.Run(tt.name+"/"+string(strategy), func(t *testing.T) {
+				for seed := int64(1); seed <= 10; seed++ {
+					config := NewBuilderConfig(12, 10)
+					config.Seed = seed
+					config.DeadlyCells = 6
+					config.FreezingCells = 4
+					config.Algorithm = AlgorithmPrim // Plenty of dead ends for PlacementDeadEnds
+					config.Placement = PlacementConfig{Strategy: strategy, SafePath: tt.mode}
+
+					layout, err := Build(config)
+					if err != nil {
+						t.Fatalf("seed %d: Build: %v", seed, err)
+					}
+
+					deadly, freezing := specialCells(layout)
+					if len(deadly) != config.DeadlyCells || len(freezing) != config.FreezingCells {
+						t.Errorf("seed %d: placed %d deadly and %d freezing cells, want %d and %d",
+							seed, len(deadly), len(freezing), config.DeadlyCells, config.FreezingCells)
+					}
+
+					if tt.passable == nil {
+						continue
+					}
+					passable := func(p mazeanalysis.Point) bool {
+						return tt.passable(layout.GetCell(p.X, p.Y))
+					}
+					if _, ok := mazeanalysis.ShortestPathAvoiding(layout, config.Start, config.Exit, passable); !ok {
+						t.Errorf("seed %d: no safe path from start to exit", seed)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestPlacementHonorsExclusionRadii(t *testing.T) {
+	for _, strategy := range strategies {
+		t.Run(string(strategy), func(t *testing.T) {
+			for seed := int64(1); seed <= 10; seed++ {
+				config := NewBuilderConfig(14, 12)
+				config.Seed = seed
+				config.DeadlyCells = 5
+				config.FreezingCells = 5
+				config.Algorithm = AlgorithmPrim
+				config.Collectibles = []mazeanalysis.Point{{X: 6, Y: 5}, {X: 2, Y: 9}}
+				config.Placement = PlacementConfig{
+					Strategy:                   strategy,
+					SafePath:                   SafePathNone,
+					StartExclusionRadius:       4,
+					ExitExclusionRadius:        3,
+					CollectibleExclusionRadius: 2,
+				}
+
+				layout, err := Build(config)
+				if err != nil {
+					t.Fatalf("seed %d: Build: %v", seed, err)
+				}
+
+				zones := []struct {
+					name      string
+					distances mazeanalysis.DistanceMap
+					radius    int
+				}{
+					{"start", mazeanalysis.Distances(layout, config.Start), config.Placement.StartExclusionRadius},
+					{"exit", mazeanalysis.Distances(layout, config.Exit), config.Placement.ExitExclusionRadius},
+					{"collectible", mazeanalysis.MultiSourceDistances(layout, config.Collectibles), config.Placement.CollectibleExclusionRadius},
+				}
+
+				deadly, freezing := specialCells(layout)
+				for _, p := range append(deadly, freezing...) {
+					for _, zone := range zones {
+						if d := zone.distances.At(p); d <= zone.radius {
+							t.Errorf("seed %d: special cell %v is %d steps from the %s, radius %d", seed, p, d, zone.name, zone.radius)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestPlacementReportsUnsatisfiableConstraints(t *testing.T) {
+	// A single corridor: every cell between the start and the exit is on the only path
+	corridor := func(deadly, freezing int, placement PlacementConfig) *BuilderConfig {
+		config := NewBuilderConfig(1, 6)
+		config.Seed = 1
+		config.DeadlyCells = deadly
+		config.FreezingCells = freezing
+		config.Placement = placement
+		return config
+	}
+
+	tests := []struct {
+		name   string
+		config *BuilderConfig
+	}{
+		{
+			name:   "deadly cell on the only path",
+			config: corridor(1, 0, PlacementConfig{}),
+		},
+		{
+			name:   "freezing cell on the only hazard-free path",
+			config: corridor(0, 1, PlacementConfig{SafePath: SafePathHazardFree}),
+		},
+		{
+			name:   "more cells than dead ends",
+			config: corridor(1, 0, PlacementConfig{Strategy: PlacementDeadEnds, SafePath: SafePathNone}),
+		},
+		{
+			name:   "exclusion zones cover the maze",
+			config: corridor(0, 1, PlacementConfig{SafePath: SafePathNone, StartExclusionRadius: 2, ExitExclusionRadius: 3}),
+		},
+		{
+			name: "collectible exclusion covers the maze",
+			config: func() *BuilderConfig {
+				config := corridor(2, 0, PlacementConfig{SafePath: SafePathNone, CollectibleExclusionRadius: 2})
+				config.Collectibles = []mazeanalysis.Point{{X: 0, Y: 2}}
+				return config
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Build(tt.config)
+			if !errors.Is(err, ErrPlacementUnsatisfiable) {
+				t.Errorf("Build error = %v, want %v", err, ErrPlacementUnsatisfiable)
+			}
+		})
+	}
+
+	// Without the constraints the same corridor has room for the cells
+	if _, err := Build(corridor(2, 1, PlacementConfig{SafePath: SafePathNone})); err != nil {
+		t.Errorf("Build without constraints: %v", err)
+	}
+}
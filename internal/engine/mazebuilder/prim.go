@@ -0,0 +1,45 @@
+package mazebuilder
+
+import "math/rand"
+
+// prim carves the maze with a randomized version of Prim's algorithm.
+// The maze grows from a random cell by opening random frontier walls,
+// which produces many short branches and junctions.
+type prim struct{}
+
+type frontierWall struct {
+	cell *builderCell
+	dir  int
+}
+
+func (prim) Generate(grid builderGrid, r *rand.Rand) {
+	start := grid.randomCell(r)
+	start.visited = true
+	frontier := appendFrontierWalls(nil, grid, start)
+
+	for len(frontier) > 0 {
+		// Pick a random frontier wall and remove it from the list.
+		idx := r.Intn(len(frontier))
+		wall := frontier[idx]
+		frontier[idx] = frontier[len(frontier)-1]
+		frontier = frontier[:len(frontier)-1]
+
+		neighbor := grid.neighbor(wall.cell, wall.dir)
+		if neighbor.visited {
+			continue
+		}
+
+		removeWall(wall.cell, neighbor, wall.dir)
+		neighbor.visited = true
+		frontier = appendFrontierWalls(frontier, grid, neighbor)
+	}
+}
+
+// appendFrontierWalls adds the walls between a cell and its unvisited neighbors to the frontier
+func appendFrontierWalls(frontier []frontierWall, grid builderGrid, cell *builderCell) []frontierWall {
+	_, directions := grid.unvisitedNeighbors(cell)
+	for _, dir := range directions {
+		frontier = append(frontier, frontierWall{cell: cell, dir: dir})
+	}
+	return frontier
+}
@@ -0,0 +1,47 @@
+package mazebuilder
+
+import "math/rand"
+
+// wilson carves the maze with Wilson's algorithm of loop-erased random walks.
+// It generates a uniform spanning tree, so the maze has no texture bias at all.
+type wilson struct{}
+
+func (wilson) Generate(grid builderGrid, r *rand.Rand) {
+	cols, rows := grid.cols(), grid.rows()
+
+	grid.randomCell(r).visited = true
+
+	// walkDirection remembers the last direction taken out of each cell during a walk,
+	// which erases loops implicitly when the walk is retraced.
+	walkDirection := make([]int, cols*rows)
+
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			if grid[y][x].visited {
+				continue
+			}
+
+			// Random walk until the walk hits the maze.
+			current := grid[y][x]
+			for !current.visited {
+				dir := r.Intn(4)
+				next := grid.neighbor(current, dir)
+				if next == nil {
+					continue
+				}
+				walkDirection[current.y*cols+current.x] = dir
+				current = next
+			}
+
+			// Carve the loop-erased walk into the maze.
+			current = grid[y][x]
+			for !current.visited {
+				dir := walkDirection[current.y*cols+current.x]
+				next := grid.neighbor(current, dir)
+				removeWall(current, next, dir)
+				current.visited = true
+				current = next
+			}
+		}
+	}
+}
@@ -64,3 +64,13 @@ func GetImage(image GameImage) *ebiten.Image {
 	}
 	return img
 }
+
+// LookupImage returns which game image a cached image is, so references to it can be saved
+func LookupImage(img *ebiten.Image) (GameImage, bool) {
+	for image, cached := range gameImages {
+		if cached == img {
+			return image, true
+		}
+	}
+	return 0, false
+}
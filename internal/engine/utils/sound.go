@@ -17,6 +17,9 @@ var (
 	audioContext = audio.NewContext(sampleRate)
 	players      = map[SoundEffect]*audio.Player{}
 	musicPlayer  *audio.Player
+
+	musicEnabled  = true
+	soundsEnabled = true
 )
 
 type SoundEffect int
@@ -66,6 +69,10 @@ func loadSound(sound SoundEffect) error {
 }
 
 func PlaySound(sound SoundEffect) {
+	if !soundsEnabled {
+		return
+	}
+
 	player, exists := players[sound]
 	if !exists {
 		log.Printf("sound %d not loaded", sound)
@@ -84,6 +91,10 @@ func PlaySound(sound SoundEffect) {
 
 // StartBackgroundMusic starts playing the background music in a loop
 func StartBackgroundMusic() {
+	if !musicEnabled {
+		return
+	}
+
 	if musicPlayer != nil {
 		// Music is already loaded, just start playing
 		if !musicPlayer.IsPlaying() {
@@ -116,6 +127,45 @@ func StopBackgroundMusic() {
 	}
 }
 
+// ResumeBackgroundMusic plays the background music from where it was stopped
+func ResumeBackgroundMusic() {
+	if !musicEnabled {
+		return
+	}
+	if musicPlayer == nil {
+		StartBackgroundMusic()
+		return
+	}
+
+	if !musicPlayer.IsPlaying() {
+		musicPlayer.Play()
+	}
+}
+
+// SetMusicEnabled turns the background music on or off. Turning it off stops the music,
+// turning it on lets the next StartBackgroundMusic or ResumeBackgroundMusic play it.
+func SetMusicEnabled(enabled bool) {
+	musicEnabled = enabled
+	if !enabled {
+		StopBackgroundMusic()
+	}
+}
+
+// IsMusicEnabled returns true if the background music is turned on
+func IsMusicEnabled() bool {
+	return musicEnabled
+}
+
+// SetSoundsEnabled turns the sound effects on or off
+func SetSoundsEnabled(enabled bool) {
+	soundsEnabled = enabled
+}
+
+// IsSoundsEnabled returns true if the sound effects are turned on
+func IsSoundsEnabled() bool {
+	return soundsEnabled
+}
+
 // loadBackgroundMusic loads the background music file
 func loadBackgroundMusic() error {
 	if musicPlayer != nil {
@@ -0,0 +1,135 @@
+// Package achievements unlocks achievements from the events of a run. Every achievement
+// has a declarative rule: when it is checked and what has to be counted by then.
+package achievements
+
+import "github.com/juanancid/maze-adventure/internal/gameplay/config"
+
+// Stat is something counted from the events of a run
+type Stat string
+
+const (
+	StatDamage             Stat = "damage"             // Hits and expired timers
+	StatFreezes            Stat = "freezes"            // Times the player was frozen
+	StatCollectiblesPicked Stat = "collectiblesPicked" // Collectibles worth points picked
+	StatCollectiblesLeft   Stat = "collectiblesLeft"   // Collectibles worth points not picked, only counted over a level
+	StatCellsEntered       Stat = "cellsEntered"       // Moves from a cell of the maze to another
+)
+
+// Scope is how much of the run a stat is counted over
+type Scope int
+
+const (
+	ScopeLevel Scope = iota // Since the current level started
+	ScopeRun                // Since the run started. Only runs played from their first level in one go count.
+)
+
+// Op compares a stat with the value of a condition
+type Op int
+
+const (
+	AtMost Op = iota
+	AtLeast
+)
+
+// Condition is a limit on a stat
+type Condition struct {
+	Stat  Stat
+	Scope Scope
+	Op    Op
+	Value int
+}
+
+// Trigger is when a rule is checked
+type Trigger int
+
+const (
+	OnLevelCompleted Trigger = iota
+	OnRunFinished            // Only runs played from their first level in one go are checked
+)
+
+// Rule describes when an achievement unlocks: it is checked at its trigger, in the runs and
+// levels it applies to, and unlocks if every condition is met
+type Rule struct {
+	On         Trigger
+	Level      int         // Level the rule is checked at, 0 for any
+	Mode       config.Mode // Mode of the runs the rule is checked in, empty for any
+	Difficulty string      // Name of the difficulty of the runs the rule is checked in, empty for any
+	Victory    bool        // Whether the run has to be won, for rules checked when it finishes
+	Conditions []Condition
+}
+
+// Achievement is a goal the player unlocks once, kept across runs
+type Achievement struct {
+	ID          string // Name the unlock is saved under, never changed once released
+	Title       string
+	Description string
+	Rule        Rule
+}
+
+// Defaults returns the achievements of the game
+func Defaults() []Achievement {
+	return []Achievement{
+		{
+			ID:          "first-steps",
+			Title:       "FIRST STEPS",
+			Description: "Complete a sector",
+			Rule:        Rule{On: OnLevelCompleted},
+		},
+		{
+			ID:          "untouched",
+			Title:       "UNTOUCHED",
+			Description: "Complete a sector without taking damage",
+			Rule: Rule{
+				On:         OnLevelCompleted,
+				Conditions: []Condition{{Stat: StatDamage, Scope: ScopeLevel, Op: AtMost, Value: 0}},
+			},
+		},
+		{
+			ID:          "sector-4-sweep",
+			Title:       "SECTOR 4 SWEEP",
+			Description: "Recover every data fragment in sector 4 of the campaign",
+			Rule: Rule{
+				On:         OnLevelCompleted,
+				Level:      4,
+				Mode:       config.ModeCampaign,
+				Conditions: []Condition{{Stat: StatCollectiblesLeft, Scope: ScopeLevel, Op: AtMost, Value: 0}},
+			},
+		},
+		{
+			ID:          "warm-blooded",
+			Title:       "WARM BLOODED",
+			Description: "Win a run without getting frozen",
+			Rule: Rule{
+				On:         OnRunFinished,
+				Victory:    true,
+				Conditions: []Condition{{Stat: StatFreezes, Scope: ScopeRun, Op: AtMost, Value: 0}},
+			},
+		},
+		{
+			ID:          "hard-boiled",
+			Title:       "HARD BOILED",
+			Description: "Beat the campaign on hard",
+			Rule: Rule{
+				On:         OnRunFinished,
+				Mode:       config.ModeCampaign,
+				Difficulty: config.DifficultyHard,
+				Victory:    true,
+			},
+		},
+		{
+			ID:          "wanderer",
+			Title:       "WANDERER",
+			Description: "Walk through 500 cells in a single run",
+			Rule: Rule{
+				On:         OnLevelCompleted,
+				Conditions: []Condition{{Stat: StatCellsEntered, Scope: ScopeRun, Op: AtLeast, Value: 500}},
+			},
+		},
+		{
+			ID:          "deep-diver",
+			Title:       "DEEP DIVER",
+			Description: "Clear depth 10 of an endless run",
+			Rule:        Rule{On: OnLevelCompleted, Level: 10, Mode: config.ModeEndless},
+		},
+	}
+}
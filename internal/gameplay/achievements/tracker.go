@@ -0,0 +1,169 @@
+package achievements
+
+import (
+	"reflect"
+
+	"github.com/juanancid/maze-adventure/internal/gameplay/config"
+	"github.com/juanancid/maze-adventure/internal/gameplay/events"
+)
+
+// counts are the stats counted over a scope
+type counts map[Stat]int
+
+// Tracker follows a run through the events of its bus, counting stats and unlocking the
+// achievements whose rules are met
+type Tracker struct {
+	achievements []Achievement
+	unlocked     map[string]bool
+	onUnlock     func(Achievement)
+
+	mode       config.Mode
+	difficulty string
+
+	level        int
+	levelCounts  counts
+	runCounts    counts
+	wholeRun     bool // Whether the run was followed from its first level, run stats count all of it
+	collectibles int  // Collectibles worth points the current level started with
+}
+
+// NewTracker creates a tracker for a run of the given configuration. Achievements with an ID in
+// unlocked are never unlocked again, and onUnlock is called for every other one once it unlocks.
+func NewTracker(achievements []Achievement, unlocked []string, gameConfig config.GameConfig, onUnlock func(Achievement)) *Tracker {
+	t := &Tracker{
+		achievements: achievements,
+		unlocked:     make(map[string]bool),
+		onUnlock:     onUnlock,
+		mode:         gameConfig.Mode,
+		difficulty:   gameConfig.Difficulty.OrNormal().Name,
+		levelCounts:  make(counts),
+		runCounts:    make(counts),
+	}
+	if t.mode == "" {
+		t.mode = config.ModeCampaign
+	}
+	for _, id := range unlocked {
+		t.unlocked[id] = true
+	}
+	return t
+}
+
+// Subscribe starts following the events of the bus
+func (t *Tracker) Subscribe(bus *events.Bus) {
+	bus.Subscribe(reflect.TypeOf(events.LevelStarted{}), t.onLevelStarted)
+	bus.Subscribe(reflect.TypeOf(events.PlayerEnteredCell{}), t.onPlayerEnteredCell)
+	bus.Subscribe(reflect.TypeOf(events.CollectiblePicked{}), t.onCollectiblePicked)
+	bus.Subscribe(reflect.TypeOf(events.PlayerDamaged{}), t.onPlayerDamaged)
+	bus.Subscribe(reflect.TypeOf(events.TimerExpired{}), t.onTimerExpired)
+	bus.Subscribe(reflect.TypeOf(events.PlayerFrozen{}), t.onPlayerFrozen)
+	bus.Subscribe(reflect.TypeOf(events.LevelCompletedEvent{}), t.onLevelCompleted)
+	bus.Subscribe(reflect.TypeOf(events.RunFinished{}), t.onRunFinished)
+}
+
+func (t *Tracker) onLevelStarted(e events.Event) {
+	started := e.(events.LevelStarted)
+	if started.NewRun {
+		t.runCounts = make(counts)
+		t.wholeRun = started.Level == 1
+	}
+
+	t.level = started.Level
+	t.levelCounts = make(counts)
+	t.collectibles = started.Collectibles
+}
+
+func (t *Tracker) onPlayerEnteredCell(e events.Event) {
+	t.count(StatCellsEntered, 1)
+}
+
+func (t *Tracker) onCollectiblePicked(e events.Event) {
+	t.count(StatCollectiblesPicked, 1)
+}
+
+func (t *Tracker) onPlayerDamaged(e events.Event) {
+	// Harmless contacts are not damage
+	if e.(events.PlayerDamaged).Amount > 0 {
+		t.count(StatDamage, 1)
+	}
+}
+
+func (t *Tracker) onTimerExpired(e events.Event) {
+	t.count(StatDamage, 1)
+}
+
+func (t *Tracker) onPlayerFrozen(e events.Event) {
+	t.count(StatFreezes, 1)
+}
+
+func (t *Tracker) onLevelCompleted(e events.Event) {
+	t.check(OnLevelCompleted, false)
+}
+
+func (t *Tracker) onRunFinished(e events.Event) {
+	t.check(OnRunFinished, e.(events.RunFinished).Victory)
+}
+
+// count adds to a stat of the level and of the run
+func (t *Tracker) count(stat Stat, n int) {
+	t.levelCounts[stat] += n
+	t.runCounts[stat] += n
+}
+
+// check unlocks the achievements not unlocked yet whose rules are met at the trigger
+func (t *Tracker) check(trigger Trigger, victory bool) {
+	for _, achievement := range t.achievements {
+		if t.unlocked[achievement.ID] || !t.met(achievement.Rule, trigger, victory) {
+			continue
+		}
+
+		t.unlocked[achievement.ID] = true
+		if t.onUnlock != nil {
+			t.onUnlock(achievement)
+		}
+	}
+}
+
+// met returns true if the rule applies to the trigger and to this run, and every condition holds
+func (t *Tracker) met(rule Rule, trigger Trigger, victory bool) bool {
+	switch {
+	case rule.On != trigger:
+		return false
+	case trigger == OnRunFinished && !t.wholeRun:
+		return false
+	case rule.Level != 0 && rule.Level != t.level:
+		return false
+	case rule.Mode != "" && rule.Mode != t.mode:
+		return false
+	case rule.Difficulty != "" && rule.Difficulty != t.difficulty:
+		return false
+	case rule.Victory && !victory:
+		return false
+	}
+
+	for _, condition := range rule.Conditions {
+		value, counted := t.value(condition)
+		if !counted {
+			return false
+		}
+		if condition.Op == AtMost && value > condition.Value || condition.Op == AtLeast && value < condition.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// value returns the stat of the condition over its scope, and false if it was not counted
+// over all of it
+func (t *Tracker) value(condition Condition) (int, bool) {
+	if condition.Scope == ScopeRun {
+		if !t.wholeRun || condition.Stat == StatCollectiblesLeft {
+			return 0, false
+		}
+		return t.runCounts[condition.Stat], true
+	}
+
+	if condition.Stat == StatCollectiblesLeft {
+		return t.collectibles - t.levelCounts[StatCollectiblesPicked], true
+	}
+	return t.levelCounts[condition.Stat], true
+}
@@ -0,0 +1,238 @@
+package achievements
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/juanancid/maze-adventure/internal/gameplay/config"
+	"github.com/juanancid/maze-adventure/internal/gameplay/events"
+)
+
+// cells returns n PlayerEnteredCell events
+func cells(n int) []events.Event {
+	entered := make([]events.Event, n)
+	for i := range entered {
+		entered[i] = events.PlayerEnteredCell{Col: i % 10, Row: i / 10}
+	}
+	return entered
+}
+
+// sequence flattens events and slices of events into the order they are published in
+func sequence(parts ...any) []events.Event {
+	var all []events.Event
+	for _, part := range parts {
+		switch part := part.(type) {
+		case events.Event:
+			all = append(all, part)
+		case []events.Event:
+			all = append(all, part...)
+		}
+	}
+	return all
+}
+
+var (
+	campaign  = config.GameConfig{Mode: config.ModeCampaign}
+	hard      = config.GameConfig{Mode: config.ModeCampaign, Difficulty: config.Hard()}
+	endless   = config.GameConfig{Mode: config.ModeEndless}
+	newRun    = events.LevelStarted{Level: 1, Collectibles: 2, NewRun: true}
+	completed = events.LevelCompletedEvent{}
+	won       = events.RunFinished{Victory: true}
+	lost      = events.RunFinished{Victory: false}
+	picked    = events.CollectiblePicked{Value: 100}
+	hit       = events.PlayerDamaged{Amount: 1}
+	frozen    = events.PlayerFrozen{Duration: 1500}
+)
+
+// nextLevel starts a level of the run being played, or restarts it from a quicksave
+func nextLevel(level int) events.LevelStarted {
+	return events.LevelStarted{Level: level, Collectibles: 2}
+}
+
+// resumedRun starts a run at a level other than the first, like a run resumed from a save
+func resumedRun(level int) events.LevelStarted {
+	return events.LevelStarted{Level: level, Collectibles: 2, NewRun: true}
+}
+
+func TestTrackerUnlocks(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   config.GameConfig
+		unlocked []string
+		events   []events.Event
+		want     []string
+	}{
+		{
+			name:   "clean level",
+			config: campaign,
+			events: sequence(newRun, cells(5), picked, completed),
+			want:   []string{"first-steps", "untouched"},
+		},
+		{
+			name:   "level with a hit",
+			config: campaign,
+			events: sequence(newRun, hit, completed),
+			want:   []string{"first-steps"},
+		},
+		{
+			name:   "expired timer is damage",
+			config: campaign,
+			events: sequence(newRun, events.TimerExpired{}, completed),
+			want:   []string{"first-steps"},
+		},
+		{
+			name:   "harmless contact is not damage",
+			config: campaign,
+			events: sequence(newRun, events.PlayerDamaged{Amount: 0}, completed),
+			want:   []string{"first-steps", "untouched"},
+		},
+		{
+			name:   "damage only counts over its level",
+			config: campaign,
+			events: sequence(newRun, hit, completed, nextLevel(2), completed),
+			want:   []string{"first-steps", "untouched"},
+		},
+		{
+			name:     "unlocked achievements are not unlocked again",
+			config:   campaign,
+			unlocked: []string{"first-steps"},
+			events:   sequence(newRun, completed, nextLevel(2), completed),
+			want:     []string{"untouched"},
+		},
+		{
+			name:     "every fragment of sector 4",
+			config:   campaign,
+			unlocked: []string{"first-steps", "untouched"},
+			events:   sequence(nextLevel(4), picked, picked, completed),
+			want:     []string{"sector-4-sweep"},
+		},
+		{
+			name:     "a fragment left in sector 4",
+			config:   campaign,
+			unlocked: []string{"first-steps", "untouched"},
+			events:   sequence(nextLevel(4), picked, completed),
+		},
+		{
+			name:     "every fragment of another sector",
+			config:   campaign,
+			unlocked: []string{"first-steps", "untouched"},
+			events:   sequence(nextLevel(3), picked, picked, completed),
+		},
+		{
+			name:     "every fragment of sector 4 outside the campaign",
+			config:   endless,
+			unlocked: []string{"first-steps", "untouched"},
+			events:   sequence(nextLevel(4), picked, picked, completed),
+		},
+		{
+			name:     "endless depth 10",
+			config:   endless,
+			unlocked: []string{"first-steps", "untouched"},
+			events:   sequence(nextLevel(10), completed),
+			want:     []string{"deep-diver"},
+		},
+		{
+			name:     "campaign won on hard",
+			config:   hard,
+			unlocked: []string{"first-steps", "untouched"},
+			events:   sequence(newRun, completed, won),
+			want:     []string{"warm-blooded", "hard-boiled"},
+		},
+		{
+			name:     "campaign won on hard after a freeze",
+			config:   hard,
+			unlocked: []string{"first-steps", "untouched"},
+			events:   sequence(newRun, frozen, completed, won),
+			want:     []string{"hard-boiled"},
+		},
+		{
+			name:     "campaign won on normal",
+			config:   campaign,
+			unlocked: []string{"first-steps", "untouched"},
+			events:   sequence(newRun, completed, won),
+			want:     []string{"warm-blooded"},
+		},
+		{
+			name:     "campaign lost on hard",
+			config:   hard,
+			unlocked: []string{"first-steps", "untouched"},
+			events:   sequence(newRun, lost),
+		},
+		{
+			name:   "cells counted over the whole run",
+			config: campaign,
+			events: sequence(newRun, cells(300), completed, nextLevel(2), cells(200), completed),
+			want:   []string{"first-steps", "untouched", "wanderer"},
+		},
+		{
+			name:   "cells counted over a quicksave",
+			config: campaign,
+			events: sequence(newRun, cells(300), nextLevel(1), cells(200), completed),
+			want:   []string{"first-steps", "untouched", "wanderer"},
+		},
+		{
+			name:   "cells of a previous run",
+			config: campaign,
+			events: sequence(newRun, cells(400), lost, newRun, cells(100), completed),
+			want:   []string{"first-steps", "untouched"},
+		},
+		{
+			name:     "freeze of a previous run",
+			config:   campaign,
+			unlocked: []string{"first-steps", "untouched"},
+			events:   sequence(newRun, frozen, lost, newRun, completed, won),
+			want:     []string{"warm-blooded"},
+		},
+		{
+			name:   "run resumed from a save",
+			config: hard,
+			events: sequence(resumedRun(3), cells(500), completed, won),
+			want:   []string{"first-steps", "untouched"},
+		},
+		{
+			name:     "run resumed after a whole run",
+			config:   campaign,
+			unlocked: []string{"first-steps", "untouched"},
+			events:   sequence(newRun, cells(400), lost, resumedRun(2), cells(100), completed, won),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := events.NewBus()
+			var got []string
+			tracker := NewTracker(Defaults(), tt.unlocked, tt.config, func(achievement Achievement) {
+				got = append(got, achievement.ID)
+			})
+			tracker.Subscribe(bus)
+
+			for _, event := range tt.events {
+				bus.Publish(event)
+			}
+			bus.Process()
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("unlocked %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrackerUnlocksOnce(t *testing.T) {
+	bus := events.NewBus()
+	var got []string
+	tracker := NewTracker(Defaults(), nil, campaign, func(achievement Achievement) {
+		got = append(got, achievement.ID)
+	})
+	tracker.Subscribe(bus)
+
+	for level := 1; level <= 3; level++ {
+		bus.Publish(events.LevelStarted{Level: level, NewRun: level == 1})
+		bus.Publish(completed)
+		bus.Process()
+	}
+
+	if want := []string{"first-steps", "untouched"}; !slices.Equal(got, want) {
+		t.Errorf("unlocked %v, want %v", got, want)
+	}
+}
@@ -1,7 +1,20 @@
 package config
 
+// Mode is the kind of run being played
+type Mode string
+
+const (
+	ModeCampaign   Mode = "campaign"   // The levels of the pack, one after the other
+	ModeEndless    Mode = "endless"    // Levels synthesized one after the other until the player runs out of hearts
+	ModeDaily      Mode = "daily"      // The same short run for everyone on a given day, one attempt each
+	ModeTimeAttack Mode = "timeattack" // The levels of the pack against the clock, with no hearts to lose
+)
+
 // GameConfig holds the game's configuration
 type GameConfig struct {
-	StartingHearts int // Number of hearts the player starts with
-	StartingLevel  int // Level to start the game at (1-4, default: 1)
+	Mode             Mode       // Kind of run started by new games, empty is ModeCampaign
+	Difficulty       Difficulty // Difficulty of new runs
+	CustomDifficulty Difficulty // Values of the custom difficulty, offered along with the presets
+	StartingLevel    int        // Level of the pack to start the game at (default: 1)
+	Seed             int64      // Seed for the whole run, 0 picks a random one
 }
@@ -0,0 +1,169 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Names of the difficulty presets
+const (
+	DifficultyEasy   = "easy"
+	DifficultyNormal = "normal"
+	DifficultyHard   = "hard"
+	DifficultyCustom = "custom"
+)
+
+// Difficulty scales the parameters of the session and of every level built for it
+type Difficulty struct {
+	Name            string        `json:"name"`
+	StartingHearts  int           `json:"startingHearts"`
+	TimerScale      float64       `json:"timerScale"`      // Level timers are multiplied by it, above 1 gives more time
+	PatrollerSpeed  float64       `json:"patrollerSpeed"`  // Patroller speeds are multiplied by it
+	PatrollerDamage int           `json:"patrollerDamage"` // Hearts taken by a patroller on contact
+	FreezeDuration  time.Duration `json:"freezeDuration"`  // How long a freezing cell immobilizes the player
+	DamageCooldown  time.Duration `json:"damageCooldown"`  // How long the player is safe after taking damage
+	HazardScale     float64       `json:"hazardScale"`     // Deadly cells, freezing cells and patrollers of generated levels are multiplied by it
+}
+
+// Easy gives more hearts and time against fewer, slower hazards
+func Easy() Difficulty {
+	return Difficulty{
+		Name:            DifficultyEasy,
+		StartingHearts:  5,
+		TimerScale:      1.5,
+		PatrollerSpeed:  0.75,
+		PatrollerDamage: 1,
+		FreezeDuration:  1500 * time.Millisecond,
+		DamageCooldown:  2500 * time.Millisecond,
+		HazardScale:     0.5,
+	}
+}
+
+// Normal plays the levels as they are configured
+func Normal() Difficulty {
+	return Difficulty{
+		Name:            DifficultyNormal,
+		StartingHearts:  3,
+		TimerScale:      1,
+		PatrollerSpeed:  1,
+		PatrollerDamage: 1,
+		FreezeDuration:  2500 * time.Millisecond,
+		DamageCooldown:  1500 * time.Millisecond,
+		HazardScale:     1,
+	}
+}
+
+// Hard gives fewer hearts and less time against more, faster hazards
+func Hard() Difficulty {
+	return Difficulty{
+		Name:            DifficultyHard,
+		StartingHearts:  2,
+		TimerScale:      0.75,
+		PatrollerSpeed:  1.25,
+		PatrollerDamage: 2,
+		FreezeDuration:  3500 * time.Millisecond,
+		DamageCooldown:  1000 * time.Millisecond,
+		HazardScale:     1.5,
+	}
+}
+
+// Presets returns the built-in difficulties, easiest first
+func Presets() []Difficulty {
+	return []Difficulty{Easy(), Normal(), Hard()}
+}
+
+// ParseDifficulty reads a difficulty from its name. A custom difficulty starts from Normal
+// and can change any of its values: "custom:hearts=5,timer=1.2,speed=0.9,damage=1,freeze=2s,cooldown=1s,hazards=0.5".
+func ParseDifficulty(s string) (Difficulty, error) {
+	name, values, hasValues := strings.Cut(s, ":")
+	name = strings.ToLower(name)
+	if name == DifficultyCustom {
+		return parseCustomDifficulty(values)
+	}
+
+	for _, preset := range Presets() {
+		if preset.Name != name {
+			continue
+		}
+		if hasValues {
+			return Difficulty{}, fmt.Errorf("only the %s difficulty takes values, got %q", DifficultyCustom, s)
+		}
+		return preset, nil
+	}
+	return Difficulty{}, fmt.Errorf("unknown difficulty %q, expected %s, %s, %s or %s", name, DifficultyEasy, DifficultyNormal, DifficultyHard, DifficultyCustom)
+}
+
+// parseCustomDifficulty reads the comma separated key=value changes to Normal of a custom difficulty
+func parseCustomDifficulty(values string) (Difficulty, error) {
+	difficulty := Normal()
+	difficulty.Name = DifficultyCustom
+	if values == "" {
+		return difficulty, nil
+	}
+
+	for _, pair := range strings.Split(values, ",") {
+		key, value, found := strings.Cut(pair, "=")
+		if !found {
+			return Difficulty{}, fmt.Errorf("custom difficulty value %q is not key=value", pair)
+		}
+
+		var err error
+		switch strings.TrimSpace(key) {
+		case "hearts":
+			difficulty.StartingHearts, err = strconv.Atoi(value)
+		case "timer":
+			difficulty.TimerScale, err = strconv.ParseFloat(value, 64)
+		case "speed":
+			difficulty.PatrollerSpeed, err = strconv.ParseFloat(value, 64)
+		case "damage":
+			difficulty.PatrollerDamage, err = strconv.Atoi(value)
+		case "freeze":
+			difficulty.FreezeDuration, err = time.ParseDuration(value)
+		case "cooldown":
+			difficulty.DamageCooldown, err = time.ParseDuration(value)
+		case "hazards":
+			difficulty.HazardScale, err = strconv.ParseFloat(value, 64)
+		default:
+			return Difficulty{}, fmt.Errorf("unknown custom difficulty value %q, expected hearts, timer, speed, damage, freeze, cooldown or hazards", key)
+		}
+		if err != nil {
+			return Difficulty{}, fmt.Errorf("invalid custom difficulty value %q: %w", pair, err)
+		}
+	}
+
+	if err := difficulty.Validate(); err != nil {
+		return Difficulty{}, err
+	}
+	return difficulty, nil
+}
+
+// Validate checks the difficulty can be played
+func (d Difficulty) Validate() error {
+	switch {
+	case d.StartingHearts < 1:
+		return fmt.Errorf("starting hearts must be at least 1, got: %d", d.StartingHearts)
+	case d.TimerScale <= 0:
+		return fmt.Errorf("timer scale must be positive, got: %g", d.TimerScale)
+	case d.PatrollerSpeed <= 0:
+		return fmt.Errorf("patroller speed must be positive, got: %g", d.PatrollerSpeed)
+	case d.PatrollerDamage < 0:
+		return fmt.Errorf("patroller damage cannot be negative, got: %d", d.PatrollerDamage)
+	case d.FreezeDuration < 0:
+		return fmt.Errorf("freeze duration cannot be negative, got: %v", d.FreezeDuration)
+	case d.DamageCooldown < 0:
+		return fmt.Errorf("damage cooldown cannot be negative, got: %v", d.DamageCooldown)
+	case d.HazardScale < 0:
+		return fmt.Errorf("hazard scale cannot be negative, got: %g", d.HazardScale)
+	}
+	return nil
+}
+
+// OrNormal returns the difficulty, or Normal if it was never set
+func (d Difficulty) OrNormal() Difficulty {
+	if d == (Difficulty{}) {
+		return Normal()
+	}
+	return d
+}
@@ -46,3 +46,32 @@ type PlayerFrozen struct {
 
 // isEvent implements the Event interface explicitly.
 func (PlayerFrozen) isEvent() {}
+
+// PlayerEnteredCell indicates that the player has moved into another cell of the maze
+type PlayerEnteredCell struct {
+	Col int
+	Row int
+}
+
+// isEvent implements the Event interface explicitly.
+func (PlayerEnteredCell) isEvent() {}
+
+// LevelStarted indicates that a level is being played from its start, or from a quicksave
+type LevelStarted struct {
+	Level        int
+	Collectibles int  // Collectibles worth points left in the level
+	NewRun       bool // Whether this is the first level of a run, nothing was played before it
+}
+
+// isEvent implements the Event interface explicitly.
+func (LevelStarted) isEvent() {}
+
+// RunFinished indicates that the run is over, won or lost
+type RunFinished struct {
+	Victory bool
+	Level   int // Last level reached
+	Score   int
+}
+
+// isEvent implements the Event interface explicitly.
+func (RunFinished) isEvent() {}
@@ -0,0 +1,153 @@
+package levels
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/juanancid/maze-adventure/internal/engine/config"
+	"github.com/juanancid/maze-adventure/internal/engine/mazebuilder"
+	"github.com/juanancid/maze-adventure/internal/gameplay/levels/definitions"
+)
+
+// EndlessName is the name endless runs are saved and ranked under, apart from any level pack
+const EndlessName = "endless"
+
+// Ramp is a value that changes by the same step every level until it reaches its limit
+type Ramp struct {
+	Start    float64 // Value at the first level
+	PerLevel float64 // Change from one level to the next, negative to decrease
+	Limit    float64 // Value the ramp stops at
+}
+
+// At returns the value of the ramp at the given level (1-based)
+func (r Ramp) At(levelNumber int) float64 {
+	value := r.Start + r.PerLevel*float64(levelNumber-1)
+	if r.PerLevel < 0 {
+		return max(value, r.Limit)
+	}
+	return min(value, r.Limit)
+}
+
+// levelsToLimit returns the first level at which the ramp reaches its limit
+func (r Ramp) levelsToLimit() int {
+	if r.PerLevel == 0 {
+		return 1
+	}
+	return int(math.Ceil((r.Limit-r.Start)/r.PerLevel)) + 1
+}
+
+// Curve synthesizes an endless run: every level is built from ramps that grow the maze
+// and add hazards and timer pressure with depth
+type Curve struct {
+	Cols                  Ramp
+	Rows                  Ramp
+	ExtraConnectionChance Ramp
+	DeadlyCells           Ramp
+	FreezingCells         Ramp
+	Patrollers            Ramp
+	Collectibles          Ramp
+	TimerPerCell          Ramp // Seconds of timer for every cell of the maze
+	TimerFrom             int  // First level with a timer, 0 for none
+}
+
+// DefaultCurve starts as small as the first built-in level and goes past the last one
+// after a dozen levels
+func DefaultCurve() Curve {
+	return Curve{
+		Cols:                  Ramp{Start: 6, PerLevel: 0.75, Limit: 20},
+		Rows:                  Ramp{Start: 4, PerLevel: 0.5, Limit: 12},
+		ExtraConnectionChance: Ramp{Start: 0.02, PerLevel: 0.01, Limit: 0.2},
+		DeadlyCells:           Ramp{Start: 0, PerLevel: 0.6, Limit: 14},
+		FreezingCells:         Ramp{Start: 0, PerLevel: 0.75, Limit: 16},
+		Patrollers:            Ramp{Start: 0, PerLevel: 0.4, Limit: 8},
+		Collectibles:          Ramp{Start: 3, PerLevel: 0.5, Limit: 10},
+		TimerPerCell:          Ramp{Start: 1.5, PerLevel: -0.05, Limit: 0.6},
+		TimerFrom:             3,
+	}
+}
+
+// Level returns the configuration of the given level of the endless run. Every level number
+// from 1 on has one, the curve only stops getting harder once every ramp reaches its limit.
+func (c Curve) Level(levelNumber int) (definitions.LevelConfig, bool) {
+	if levelNumber < 1 {
+		return definitions.EmptyLevelConfig, false
+	}
+	return c.level(levelNumber), true
+}
+
+func (c Curve) level(levelNumber int) definitions.LevelConfig {
+	cols := max(int(c.Cols.At(levelNumber)), 2)
+	rows := max(int(c.Rows.At(levelNumber)), 2)
+	cellSize := min(config.ScreenWidth/cols, (config.ScreenHeight-config.HudHeight)/rows)
+
+	// Hazards stay well below the cells the maze has, so there is always room to get through
+	totalCells := cols * rows
+	deadly := int(c.DeadlyCells.At(levelNumber))
+	freezing := int(c.FreezingCells.At(levelNumber))
+	patrollers := int(c.Patrollers.At(levelNumber))
+	if hazards := deadly + freezing + patrollers; hazards > totalCells/3 {
+		scale := float64(totalCells/3) / float64(hazards)
+		deadly = int(float64(deadly) * scale)
+		freezing = int(float64(freezing) * scale)
+		patrollers = int(float64(patrollers) * scale)
+	}
+
+	timer := 0
+	if c.TimerFrom > 0 && levelNumber >= c.TimerFrom {
+		timer = max(int(math.Round(c.TimerPerCell.At(levelNumber)*float64(totalCells))), 10)
+	}
+
+	algorithms := mazebuilder.Algorithms()
+	return definitions.LevelConfig{
+		Maze: definitions.MazeConfig{
+			Cols:                  cols,
+			Rows:                  rows,
+			Algorithm:             algorithms[(levelNumber-1)%len(algorithms)],
+			DeadlyCells:           deadly,
+			FreezingCells:         freezing,
+			Patrollers:            patrollers,
+			ExtraConnectionChance: min(max(c.ExtraConnectionChance.At(levelNumber), 0), 1),
+			Placement: mazebuilder.PlacementConfig{
+				StartExclusionRadius: 2,
+				ExitExclusionRadius:  1,
+			},
+		},
+		Player: definitions.PlayerConfig{
+			Size: min(12, cellSize/2),
+		},
+		Exit: definitions.ExitConfig{
+			Position: definitions.Coordinate{X: cols - 1, Y: rows - 1},
+			Size:     min(16, cellSize-2),
+		},
+		Collectibles: definitions.Collectibles{
+			Number: int(c.Collectibles.At(levelNumber)),
+			Size:   min(8, cellSize/2),
+			Value:  1,
+		},
+		Timer: timer,
+	}
+}
+
+// Validate checks every level the curve can generate, up to the level where every ramp
+// reaches its limit and the levels stop changing but for the maze algorithm
+func (c Curve) Validate() error {
+	last := 1
+	for _, ramp := range []Ramp{c.Cols, c.Rows, c.ExtraConnectionChance, c.DeadlyCells, c.FreezingCells, c.Patrollers, c.Collectibles, c.TimerPerCell} {
+		if ramp.PerLevel != 0 && (ramp.Limit-ramp.Start)/ramp.PerLevel < 0 {
+			return fmt.Errorf("ramp %+v moves away from its limit", ramp)
+		}
+		last = max(last, ramp.levelsToLimit())
+	}
+	last = max(last, c.TimerFrom) + len(mazebuilder.Algorithms())
+
+	for levelNumber := 1; levelNumber <= last; levelNumber++ {
+		levelConfig := c.level(levelNumber)
+		if err := levelConfig.Maze.Validate(); err != nil {
+			return fmt.Errorf("endless level %d: %w", levelNumber, err)
+		}
+		if err := levelConfig.Validate(); err != nil {
+			return fmt.Errorf("endless level %d does not fit on screen: %w", levelNumber, err)
+		}
+	}
+	return nil
+}
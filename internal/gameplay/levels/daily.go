@@ -0,0 +1,45 @@
+package levels
+
+import (
+	"time"
+
+	"github.com/juanancid/maze-adventure/internal/gameplay/levels/definitions"
+)
+
+const (
+	// DailyName is the name daily challenges are saved under, apart from any level pack
+	DailyName = "daily"
+	// DailyLevels is how many levels a daily challenge has
+	DailyLevels = 5
+)
+
+// Daily is the short run of a daily challenge: levels of the curve picked at increasing depths.
+// Playing it with the seed of the day gives everyone the same levels.
+type Daily struct {
+	Curve Curve
+}
+
+// Level returns the configuration of a level of the daily challenge, and false past the last one
+func (d Daily) Level(levelNumber int) (definitions.LevelConfig, bool) {
+	if levelNumber < 1 || levelNumber > DailyLevels {
+		return definitions.EmptyLevelConfig, false
+	}
+	return d.Curve.Level(2*levelNumber + 1)
+}
+
+// DailyDate returns the UTC day of the given time, which names its daily challenge
+func DailyDate(t time.Time) string {
+	return t.UTC().Format(time.DateOnly)
+}
+
+// DailySeed returns the run seed of the daily challenge of the UTC day of the given time
+func DailySeed(t time.Time) int64 {
+	year, month, day := t.UTC().Date()
+
+	// splitmix64 finalizer so consecutive days get unrelated seeds
+	z := uint64(year*10000+int(month)*100+day) * 0x9E3779B97F4A7C15
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	z ^= z >> 31
+	return int64(z)
+}
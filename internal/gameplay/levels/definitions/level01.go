@@ -1,11 +1,14 @@
 package definitions
 
+import "github.com/juanancid/maze-adventure/internal/engine/mazebuilder"
+
 // Level01 -> Movement and collecting (no hazards)
 func Level01() LevelConfig {
 	return LevelConfig{
 		Maze: MazeConfig{
 			Cols:                  8,
 			Rows:                  5,
+			Algorithm:             mazebuilder.AlgorithmRecursiveBacktracker,
 			DeadlyCells:           0,
 			FreezingCells:         0,
 			Patrollers:            0,
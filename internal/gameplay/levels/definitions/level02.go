@@ -1,15 +1,21 @@
 package definitions
 
+import "github.com/juanancid/maze-adventure/internal/engine/mazebuilder"
+
 // Level02 -> Introduce deadly cells
 func Level02() LevelConfig {
 	return LevelConfig{
 		Maze: MazeConfig{
 			Cols:                  10,
 			Rows:                  6,
+			Algorithm:             mazebuilder.AlgorithmHuntAndKill,
 			DeadlyCells:           3,
 			FreezingCells:         0,
 			Patrollers:            2,
 			ExtraConnectionChance: 0.04,
+			Placement: mazebuilder.PlacementConfig{
+				StartExclusionRadius: 1,
+			},
 		},
 		Player: PlayerConfig{
 			Size: 12,
@@ -1,15 +1,22 @@
 package definitions
 
+import "github.com/juanancid/maze-adventure/internal/engine/mazebuilder"
+
 // Level03 -> Introduce freezing cells
 func Level03() LevelConfig {
 	return LevelConfig{
 		Maze: MazeConfig{
 			Cols:                  12,
 			Rows:                  8,
+			Algorithm:             mazebuilder.AlgorithmGrowingTree,
 			DeadlyCells:           2,
 			FreezingCells:         4,
 			Patrollers:            4,
 			ExtraConnectionChance: 0.07,
+			Placement: mazebuilder.PlacementConfig{
+				Strategy:             mazebuilder.PlacementClustered,
+				StartExclusionRadius: 1,
+			},
 		},
 		Player: PlayerConfig{
 			Size: 12,
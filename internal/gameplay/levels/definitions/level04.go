@@ -1,15 +1,23 @@
 package definitions
 
+import "github.com/juanancid/maze-adventure/internal/engine/mazebuilder"
+
 // Level04 -> Challenge with all mechanics
 func Level04() LevelConfig {
 	return LevelConfig{
 		Maze: MazeConfig{
 			Cols:                  14,
 			Rows:                  9,
+			Algorithm:             mazebuilder.AlgorithmPrim,
 			DeadlyCells:           4,
 			FreezingCells:         6,
 			Patrollers:            4,
 			ExtraConnectionChance: 0.12,
+			Placement: mazebuilder.PlacementConfig{
+				Strategy:             mazebuilder.PlacementCriticalPath,
+				StartExclusionRadius: 2,
+				ExitExclusionRadius:  1,
+			},
 		},
 		Player: PlayerConfig{
 			Size: 12,
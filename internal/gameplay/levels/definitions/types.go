@@ -1,28 +1,80 @@
 package definitions
 
 import (
+	"errors"
 	"fmt"
+
+	"github.com/juanancid/maze-adventure/internal/engine/config"
+	"github.com/juanancid/maze-adventure/internal/engine/mazeascii"
+	"github.com/juanancid/maze-adventure/internal/engine/mazebuilder"
 )
 
 var EmptyLevelConfig = LevelConfig{}
 
 // LevelConfig represents the configuration for a game level
 type LevelConfig struct {
-	Maze         MazeConfig
-	Player       PlayerConfig
-	Exit         ExitConfig
-	Collectibles Collectibles
-	Timer        int // Timer in seconds, 0 means no timer for this level
+	Maze         MazeConfig   `json:"maze" yaml:"maze"`
+	Player       PlayerConfig `json:"player" yaml:"player"`
+	Exit         ExitConfig   `json:"exit" yaml:"exit"`
+	Collectibles Collectibles `json:"collectibles" yaml:"collectibles"`
+	Timer        int          `json:"timer" yaml:"timer"`                   // Timer in seconds, 0 means no timer for this level
+	Seed         int64        `json:"seed,omitempty" yaml:"seed,omitempty"` // Fixed seed for this level, 0 means it is derived from the run seed
+}
+
+// Validate ensures the whole level configuration is valid and fits on screen
+func (l LevelConfig) Validate() error {
+	if err := l.Maze.Validate(); err != nil {
+		return fmt.Errorf("invalid maze: %w", err)
+	}
+
+	var errs []error
+
+	cellWidth := config.ScreenWidth / l.Maze.Cols
+	cellHeight := (config.ScreenHeight - config.HudHeight) / l.Maze.Rows
+	if l.Player.Size <= 0 || l.Player.Size >= cellWidth || l.Player.Size >= cellHeight {
+		errs = append(errs, fmt.Errorf("player size must be between 1 and %d for a %dx%d maze, got: %d", min(cellWidth, cellHeight)-1, l.Maze.Cols, l.Maze.Rows, l.Player.Size))
+	}
+
+	exit := l.Exit.Position
+	fixed, _, _ := l.Maze.FixedMap() // Already validated along with the maze
+	if fixed.Exit != nil {
+		exit = Coordinate{X: fixed.Exit.X, Y: fixed.Exit.Y}
+	}
+	if exit.X < 0 || exit.X >= l.Maze.Cols || exit.Y < 0 || exit.Y >= l.Maze.Rows {
+		errs = append(errs, fmt.Errorf("exit position (%d,%d) is outside the %dx%d maze", exit.X, exit.Y, l.Maze.Cols, l.Maze.Rows))
+	}
+	if l.Exit.Size <= 0 {
+		errs = append(errs, fmt.Errorf("exit size must be positive, got: %d", l.Exit.Size))
+	}
+
+	if l.Collectibles.Number < 0 {
+		errs = append(errs, fmt.Errorf("collectibles number cannot be negative, got: %d", l.Collectibles.Number))
+	}
+	if len(fixed.Collectibles) > 0 && l.Collectibles.Number != 0 && l.Collectibles.Number != len(fixed.Collectibles) {
+		errs = append(errs, fmt.Errorf("layout marks %d collectibles but %d are configured", len(fixed.Collectibles), l.Collectibles.Number))
+	}
+	if (l.Collectibles.Number > 0 || len(fixed.Collectibles) > 0) && l.Collectibles.Size <= 0 {
+		errs = append(errs, fmt.Errorf("collectibles size must be positive, got: %d", l.Collectibles.Size))
+	}
+
+	if l.Timer < 0 {
+		errs = append(errs, fmt.Errorf("timer cannot be negative, got: %d", l.Timer))
+	}
+
+	return errors.Join(errs...)
 }
 
 // MazeConfig defines the maze dimensions and special cells
 type MazeConfig struct {
-	Cols                  int     // Number of columns in the maze
-	Rows                  int     // Number of rows in the maze
-	DeadlyCells           int     // Number of deadly cells to place
-	FreezingCells         int     // Number of freezing cells to place
-	Patrollers            int     // Number of patroller NPCs to place
-	ExtraConnectionChance float64 // Probability (0.0-1.0) of adding extra connections between cells
+	Cols                  int                         `json:"cols" yaml:"cols"`                                   // Number of columns in the maze
+	Rows                  int                         `json:"rows" yaml:"rows"`                                   // Number of rows in the maze
+	Algorithm             mazebuilder.Algorithm       `json:"algorithm,omitempty" yaml:"algorithm,omitempty"`     // Maze generation algorithm, empty means mazebuilder.DefaultAlgorithm
+	DeadlyCells           int                         `json:"deadlyCells" yaml:"deadlyCells"`                     // Number of deadly cells to place
+	FreezingCells         int                         `json:"freezingCells" yaml:"freezingCells"`                 // Number of freezing cells to place
+	Patrollers            int                         `json:"patrollers" yaml:"patrollers"`                       // Number of patroller NPCs to place
+	ExtraConnectionChance float64                     `json:"extraConnectionChance" yaml:"extraConnectionChance"` // Probability (0.0-1.0) of adding extra connections between cells
+	Placement             mazebuilder.PlacementConfig `json:"placement,omitempty" yaml:"placement,omitempty"`     // Constraints for placing deadly and freezing cells
+	Layout                string                      `json:"layout,omitempty" yaml:"layout,omitempty"`           // Hand-drawn layout in the mazeascii format, used instead of generating the maze
 }
 
 // Validate ensures the maze configuration is valid
@@ -46,28 +98,77 @@ func (m MazeConfig) Validate() error {
 		return fmt.Errorf("extra connection chance must be between 0.0 and 1.0, got: %f", m.ExtraConnectionChance)
 	}
 
+	if !m.Algorithm.IsValid() {
+		return fmt.Errorf("unknown maze generation algorithm: %q", m.Algorithm)
+	}
+
+	if err := m.Placement.Validate(); err != nil {
+		return fmt.Errorf("invalid placement config: %w", err)
+	}
+
+	if m.Layout != "" {
+		return m.validateFixedLayout()
+	}
+
+	return nil
+}
+
+// validateFixedLayout ensures a hand-drawn layout matches the maze settings and
+// none of the generation settings are used along with it
+func (m MazeConfig) validateFixedLayout() error {
+	fixed, err := mazeascii.Parse(m.Layout)
+	if err != nil {
+		return fmt.Errorf("invalid layout: %w", err)
+	}
+
+	if fixed.Layout.Cols() != m.Cols || fixed.Layout.Rows() != m.Rows {
+		return fmt.Errorf("layout is %dx%d but the maze is configured as %dx%d", fixed.Layout.Cols(), fixed.Layout.Rows(), m.Cols, m.Rows)
+	}
+
+	if m.Algorithm != "" || m.DeadlyCells != 0 || m.FreezingCells != 0 || m.ExtraConnectionChance != 0 || m.Placement != (mazebuilder.PlacementConfig{}) {
+		return fmt.Errorf("algorithm, deadly cells, freezing cells, extra connections and placement cannot be combined with a fixed layout")
+	}
+
+	if len(fixed.Patrollers) > 0 && m.Patrollers != 0 && m.Patrollers != len(fixed.Patrollers) {
+		return fmt.Errorf("layout marks %d patroller spawns but %d patrollers are configured", len(fixed.Patrollers), m.Patrollers)
+	}
+
 	return nil
 }
 
+// FixedMap returns the hand-drawn layout of the maze, if any, along with its markers
+func (m MazeConfig) FixedMap() (mazeascii.Map, bool, error) {
+	if m.Layout == "" {
+		return mazeascii.Map{}, false, nil
+	}
+
+	fixed, err := mazeascii.Parse(m.Layout)
+	if err != nil {
+		return mazeascii.Map{}, false, fmt.Errorf("invalid layout: %w", err)
+	}
+
+	return fixed, true, nil
+}
+
 // PlayerConfig defines the player properties
 type PlayerConfig struct {
-	Size int
+	Size int `json:"size" yaml:"size"`
 }
 
 // ExitConfig defines the exit properties
 type ExitConfig struct {
-	Position Coordinate
-	Size     int
+	Position Coordinate `json:"position" yaml:"position"`
+	Size     int        `json:"size" yaml:"size"`
 }
 
 // Coordinate represents a position in the maze
 type Coordinate struct {
-	X int
-	Y int
+	X int `json:"x" yaml:"x"`
+	Y int `json:"y" yaml:"y"`
 }
 
 type Collectibles struct {
-	Number int
-	Size   int
-	Value  int
+	Number int `json:"number" yaml:"number"`
+	Size   int `json:"size" yaml:"size"`
+	Value  int `json:"value" yaml:"value"`
 }
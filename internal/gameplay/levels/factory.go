@@ -2,24 +2,37 @@ package levels
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
 
 	"github.com/hajimehoshi/ebiten/v2"
 
 	"github.com/juanancid/maze-adventure/internal/core/components"
 	"github.com/juanancid/maze-adventure/internal/core/entities"
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
 	"github.com/juanancid/maze-adventure/internal/engine/config"
+	"github.com/juanancid/maze-adventure/internal/engine/mazeanalysis"
+	"github.com/juanancid/maze-adventure/internal/engine/mazeascii"
 	"github.com/juanancid/maze-adventure/internal/engine/mazebuilder"
 	"github.com/juanancid/maze-adventure/internal/engine/utils"
+	gameplayconfig "github.com/juanancid/maze-adventure/internal/gameplay/config"
 	"github.com/juanancid/maze-adventure/internal/gameplay/levels/definitions"
 )
 
-func CreateLevel(levelConfig definitions.LevelConfig) (*entities.World, error) {
+// CreateLevel builds the world for a level. Every random decision (maze layout, hazards,
+// collectibles, patroller spawns and patroller AI) is derived from the given seed,
+// so the same configuration and seed always produce the same level.
+// Levels with a hand-drawn layout use it instead of generating the maze.
+// The hazards and patrollers are scaled by the difficulty, Normal if none is set.
+func CreateLevel(levelConfig definitions.LevelConfig, seed int64, difficulty gameplayconfig.Difficulty) (*entities.World, error) {
 	if err := levelConfig.Maze.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid level configuration: %w", err)
 	}
+	difficulty = difficulty.OrNormal()
+	levelConfig.Maze = scaleHazards(levelConfig.Maze, difficulty.HazardScale)
 
 	world := entities.NewWorld()
+	r := rand.New(rand.NewSource(seed))
 
 	mazeCols := levelConfig.Maze.Cols
 	mazeRows := levelConfig.Maze.Rows
@@ -29,30 +42,90 @@ func CreateLevel(levelConfig definitions.LevelConfig) (*entities.World, error) {
 
 	playerSize := levelConfig.Player.Size
 
-	createPlayer(world, playerSize, cellWidth, cellHeight)
+	// Collectible cells are chosen before the maze is built so hazards can keep away from them
+	mazeSeed := r.Int63()
+	cells := levelCells{
+		start:        mazeanalysis.Point{X: 0, Y: 0},
+		exit:         mazeanalysis.Point{X: levelConfig.Exit.Position.X, Y: levelConfig.Exit.Position.Y},
+		collectibles: pickCollectibleCells(levelConfig, r),
+	}
 
-	if _, err := createMaze(world, levelConfig, cellWidth, cellHeight); err != nil {
-		return nil, err
+	var layout mazelayout.Layout
+	fixed, hasFixedLayout, err := levelConfig.Maze.FixedMap()
+	if err != nil {
+		return nil, fmt.Errorf("invalid level configuration: %w", err)
+	}
+	if hasFixedLayout {
+		layout = fixed.Layout
+		cells.applyMarkers(fixed)
+	} else {
+		layout, err = buildLayout(levelConfig, cells, mazeSeed)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	createExit(world, levelConfig.Exit.Position.X, levelConfig.Exit.Position.Y, cellWidth, cellHeight, levelConfig.Exit.Size)
-	createCollectibles(world, levelConfig)
-	createPatrollers(world, levelConfig, cellWidth, cellHeight)
+	createPlayer(world, playerSize, cells.start, cellWidth, cellHeight)
+	createMaze(world, layout, cellWidth, cellHeight)
+	createExit(world, cells.exit.X, cells.exit.Y, cellWidth, cellHeight, levelConfig.Exit.Size)
+	createCollectibles(world, levelConfig, cells.collectibles)
+	createPatrollers(world, levelConfig, cells, cellWidth, cellHeight, difficulty, r)
 
 	return world, nil
 }
 
-func createPlayer(world *entities.World, playerSize, cellWidth, cellHeight int) entities.Entity {
+// scaleHazards multiplies the deadly cells, freezing cells and patrollers of a generated maze.
+// Hand-drawn layouts are kept as drawn, and so is any maze the scaled hazards would not fit in.
+func scaleHazards(maze definitions.MazeConfig, scale float64) definitions.MazeConfig {
+	if maze.Layout != "" || scale == 1 {
+		return maze
+	}
+
+	scaled := maze
+	scaled.DeadlyCells = int(math.Round(float64(maze.DeadlyCells) * scale))
+	scaled.FreezingCells = int(math.Round(float64(maze.FreezingCells) * scale))
+	scaled.Patrollers = int(math.Round(float64(maze.Patrollers) * scale))
+	if scaled.Validate() != nil {
+		return maze
+	}
+	return scaled
+}
+
+// levelCells holds the cells where the level entities are placed
+type levelCells struct {
+	start           mazeanalysis.Point
+	exit            mazeanalysis.Point
+	collectibles    []mazeanalysis.Point
+	patrollerSpawns []mazeanalysis.Point // Fixed patroller spawns, nil means they are picked at random
+}
+
+// applyMarkers replaces the default cells with the ones marked in a hand-drawn layout
+func (c *levelCells) applyMarkers(fixed mazeascii.Map) {
+	if fixed.Start != nil {
+		c.start = *fixed.Start
+	}
+	if fixed.Exit != nil {
+		c.exit = *fixed.Exit
+	}
+	if len(fixed.Collectibles) > 0 {
+		c.collectibles = fixed.Collectibles
+	}
+	if len(fixed.Patrollers) > 0 {
+		c.patrollerSpawns = fixed.Patrollers
+	}
+}
+
+func createPlayer(world *entities.World, playerSize int, start mazeanalysis.Point, cellWidth, cellHeight int) entities.Entity {
 	player := world.NewEntity()
 
-	world.AddComponent(player, &components.Size{Width: float64(playerSize), Height: float64(playerSize)})
-	world.AddComponent(player, &components.Velocity{DX: 0, DY: 0})
+	entities.Add(world, player, &components.Size{Width: float64(playerSize), Height: float64(playerSize)})
+	entities.Add(world, player, &components.Velocity{DX: 0, DY: 0})
 
-	posX := float64(cellWidth-playerSize) / 2
-	posY := float64(cellHeight-playerSize) / 2
-	world.AddComponent(player, &components.Position{X: posX, Y: posY})
+	posX := float64(start.X*cellWidth) + float64(cellWidth-playerSize)/2
+	posY := float64(start.Y*cellHeight) + float64(cellHeight-playerSize)/2
+	entities.Add(world, player, &components.Position{X: posX, Y: posY})
 
-	world.AddComponent(player, &components.InputControlled{
+	entities.Add(world, player, &components.InputControlled{
 		MoveLeftKey:  ebiten.KeyLeft,
 		MoveRightKey: ebiten.KeyRight,
 		MoveUpKey:    ebiten.KeyUp,
@@ -60,37 +133,51 @@ func createPlayer(world *entities.World, playerSize, cellWidth, cellHeight int)
 	})
 
 	playerSprite := utils.GetImage(utils.ImagePlayer)
-	world.AddComponent(player, &components.Sprite{Image: playerSprite})
+	entities.Add(world, player, &components.Sprite{Image: playerSprite})
 
 	return player
 }
 
-func createMaze(world *entities.World, levelConfig definitions.LevelConfig, cellWidth, cellHeight int) (entities.Entity, error) {
-	mazeEntity := world.NewEntity()
+// buildLayout generates the maze of a level and places its hazards
+func buildLayout(levelConfig definitions.LevelConfig, cells levelCells, seed int64) (mazelayout.Layout, error) {
 	builderConfig := mazebuilder.NewBuilderConfig(levelConfig.Maze.Cols, levelConfig.Maze.Rows)
-
-	// Set special cells and maze complexity from level configuration
+	builderConfig.Seed = seed
+	builderConfig.Start = cells.start
+	builderConfig.Exit = cells.exit
+	builderConfig.Collectibles = cells.collectibles
+	builderConfig.Placement = levelConfig.Maze.Placement
+
+	// Set generation algorithm, special cells and maze complexity from level configuration
+	if levelConfig.Maze.Algorithm != "" {
+		builderConfig.Algorithm = levelConfig.Maze.Algorithm
+	}
 	builderConfig.DeadlyCells = levelConfig.Maze.DeadlyCells
 	builderConfig.FreezingCells = levelConfig.Maze.FreezingCells
 	builderConfig.ExtraConnectionChance = levelConfig.Maze.ExtraConnectionChance
 
 	layout, err := mazebuilder.Build(builderConfig)
 	if err != nil {
-		return 0, fmt.Errorf("failed to build maze: %w", err)
+		return mazelayout.Layout{}, fmt.Errorf("failed to build maze: %w", err)
 	}
 
-	world.AddComponent(mazeEntity, &components.Maze{
+	return layout, nil
+}
+
+func createMaze(world *entities.World, layout mazelayout.Layout, cellWidth, cellHeight int) entities.Entity {
+	mazeEntity := world.NewEntity()
+
+	entities.Add(world, mazeEntity, &components.Maze{
 		Layout:     layout,
 		CellWidth:  cellWidth,
 		CellHeight: cellHeight,
 	})
 
-	return mazeEntity, nil
+	return mazeEntity
 }
 
 func createExit(world *entities.World, mazeCol, mazeRow, cellWidth, cellHeight, exitSize int) entities.Entity {
 	exit := world.NewEntity()
-	world.AddComponent(exit, &components.Size{Width: float64(exitSize), Height: float64(exitSize)})
+	entities.Add(world, exit, &components.Size{Width: float64(exitSize), Height: float64(exitSize)})
 
 	// Calculate the center position of the cell
 	cellX := float64(mazeCol * cellWidth)
@@ -100,30 +187,36 @@ func createExit(world *entities.World, mazeCol, mazeRow, cellWidth, cellHeight,
 	posX := cellX + float64(cellWidth-exitSize)/2
 	posY := cellY + float64(cellHeight-exitSize)/2
 
-	world.AddComponent(exit, &components.Position{X: posX, Y: posY})
+	entities.Add(world, exit, &components.Position{X: posX, Y: posY})
 
-	world.AddComponent(exit, &components.Exit{})
+	entities.Add(world, exit, &components.Exit{})
 
 	exitSprite := utils.GetImage(utils.ImageExit)
-	world.AddComponent(exit, &components.Sprite{Image: exitSprite})
+	entities.Add(world, exit, &components.Sprite{Image: exitSprite})
 
 	return exit
 }
 
-func createCollectibles(world *entities.World, levelConfig definitions.LevelConfig) {
+// pickCollectibleCells chooses a random cell within maze bounds for every collectible
+func pickCollectibleCells(levelConfig definitions.LevelConfig, r *rand.Rand) []mazeanalysis.Point {
+	cells := make([]mazeanalysis.Point, levelConfig.Collectibles.Number)
+	for i := range cells {
+		row := r.Intn(levelConfig.Maze.Rows)
+		col := r.Intn(levelConfig.Maze.Cols)
+		cells[i] = mazeanalysis.Point{X: col, Y: row}
+	}
+	return cells
+}
+
+func createCollectibles(world *entities.World, levelConfig definitions.LevelConfig, cells []mazeanalysis.Point) {
 	mazeCols := levelConfig.Maze.Cols
 	mazeRows := levelConfig.Maze.Rows
 
 	cellWidth := config.ScreenWidth / mazeCols
 	cellHeight := (config.ScreenHeight - config.HudHeight) / mazeRows
 
-	for i := 0; i < levelConfig.Collectibles.Number; i++ {
-		// Generate random cell coordinates within maze bounds
-		row := rand.Intn(mazeRows)
-		col := rand.Intn(mazeCols)
-
-		// Create a collectible at the random cell
-		createCollectible(world, row, col, cellWidth, cellHeight, levelConfig.Collectibles.Value, levelConfig.Collectibles.Size)
+	for _, cell := range cells {
+		createCollectible(world, cell.Y, cell.X, cellWidth, cellHeight, levelConfig.Collectibles.Value, levelConfig.Collectibles.Size)
 	}
 }
 
@@ -138,31 +231,41 @@ func createCollectible(world *entities.World, row, col, cellWidth, cellHeight, v
 	x := cellX + float64(cellWidth-size)/2
 	y := cellY + float64(cellHeight-size)/2
 
-	world.AddComponent(collectible, &components.Position{X: x, Y: y})
-	world.AddComponent(collectible, &components.Size{Width: float64(size), Height: float64(size)})
-	world.AddComponent(collectible, &components.Collectible{
+	entities.Add(world, collectible, &components.Position{X: x, Y: y})
+	entities.Add(world, collectible, &components.Size{Width: float64(size), Height: float64(size)})
+	entities.Add(world, collectible, &components.Collectible{
 		Kind:  components.CollectibleScore,
 		Value: value,
 	})
-	world.AddComponent(collectible, &components.Sprite{
+	entities.Add(world, collectible, &components.Sprite{
 		Image: utils.GetImage(utils.ImageCollectible),
 	})
 }
 
-func createPatrollers(world *entities.World, levelConfig definitions.LevelConfig, cellWidth, cellHeight int) {
+func createPatrollers(world *entities.World, levelConfig definitions.LevelConfig, cells levelCells, cellWidth, cellHeight int, difficulty gameplayconfig.Difficulty, r *rand.Rand) {
 	mazeCols := levelConfig.Maze.Cols
 	mazeRows := levelConfig.Maze.Rows
 
-	for i := 0; i < levelConfig.Maze.Patrollers; i++ {
-		// Generate random cell coordinates within maze bounds
-		row := rand.Intn(mazeRows)
-		col := rand.Intn(mazeCols)
+	count := levelConfig.Maze.Patrollers
+	if cells.patrollerSpawns != nil {
+		count = len(cells.patrollerSpawns)
+	}
 
-		// Avoid placing patrollers at the start position (0,0) or exit position
-		if (col == 0 && row == 0) || (col == levelConfig.Exit.Position.X && row == levelConfig.Exit.Position.Y) {
-			// Try next position (simple avoidance)
-			col = (col + 1) % mazeCols
-			row = (row + 1) % mazeRows
+	for i := 0; i < count; i++ {
+		var row, col int
+		if cells.patrollerSpawns != nil {
+			col, row = cells.patrollerSpawns[i].X, cells.patrollerSpawns[i].Y
+		} else {
+			// Generate random cell coordinates within maze bounds
+			row = r.Intn(mazeRows)
+			col = r.Intn(mazeCols)
+
+			// Avoid placing patrollers at the start or exit position
+			if (col == cells.start.X && row == cells.start.Y) || (col == cells.exit.X && row == cells.exit.Y) {
+				// Try next position (simple avoidance)
+				col = (col + 1) % mazeCols
+				row = (row + 1) % mazeRows
+			}
 		}
 
 		// Determine patrol pattern based on patroller ID for variety
@@ -179,11 +282,11 @@ func createPatrollers(world *entities.World, levelConfig definitions.LevelConfig
 		}
 
 		// Create a patroller at the random cell with the determined pattern
-		createPatroller(world, row, col, cellWidth, cellHeight, i, pattern)
+		createPatroller(world, row, col, cellWidth, cellHeight, i, pattern, difficulty, r.Uint64())
 	}
 }
 
-func createPatroller(world *entities.World, row, col, cellWidth, cellHeight, patrollerID int, pattern components.PatrolPattern) {
+func createPatroller(world *entities.World, row, col, cellWidth, cellHeight, patrollerID int, pattern components.PatrolPattern, difficulty gameplayconfig.Difficulty, aiSeed uint64) {
 	patroller := world.NewEntity()
 
 	// Calculate position within the cell (centered)
@@ -191,11 +294,14 @@ func createPatroller(world *entities.World, row, col, cellWidth, cellHeight, pat
 	x := float64(col*cellWidth + (cellWidth-patrollerSize)/2)
 	y := float64(row*cellHeight + (cellHeight-patrollerSize)/2)
 
-	world.AddComponent(patroller, &components.Position{X: x, Y: y})
-	world.AddComponent(patroller, &components.Size{Width: float64(patrollerSize), Height: float64(patrollerSize)})
-	world.AddComponent(patroller, &components.Velocity{DX: 0, DY: 0}) // Start stationary
+	entities.Add(world, patroller, &components.Position{X: x, Y: y})
+	entities.Add(world, patroller, &components.Size{Width: float64(patrollerSize), Height: float64(patrollerSize)})
+	entities.Add(world, patroller, &components.Velocity{DX: 0, DY: 0}) // Start stationary
 
 	// Create patroller with specific pattern and spawn position
 	patrollerComp := components.NewPatrollerWithPattern(patrollerID, pattern, col, row)
-	world.AddComponent(patroller, patrollerComp)
+	patrollerComp.Seed(aiSeed)
+	patrollerComp.Speed *= difficulty.PatrollerSpeed
+	patrollerComp.Damage = difficulty.PatrollerDamage
+	entities.Add(world, patroller, patrollerComp)
 }
@@ -6,60 +6,137 @@ import (
 	"github.com/juanancid/maze-adventure/internal/gameplay/levels/definitions"
 )
 
+// LevelSource provides the configuration of the levels of a run
+type LevelSource interface {
+	// Level returns the configuration of a level (1-based), and false if there is no such level
+	Level(levelNumber int) (definitions.LevelConfig, bool)
+}
+
 type Manager struct {
+	pack         *Pack
+	source       LevelSource // The pack itself, the curve of an endless run or a daily challenge
+	name         string
 	currentLevel int
 }
 
+// NewManager creates a level manager for the built-in levels
 func NewManager() *Manager {
+	return NewManagerForPack(DefaultPack())
+}
+
+// NewManagerForPack creates a level manager that plays the levels of the given pack
+func NewManagerForPack(pack *Pack) *Manager {
 	return &Manager{
+		pack:         pack,
+		source:       pack,
+		name:         pack.Name,
 		currentLevel: 0,
 	}
 }
 
-// NewManagerWithStartingLevel creates a new level manager starting at a specific level
+// NewManagerWithStartingLevel creates a new level manager for the built-in levels starting at a specific level
 func NewManagerWithStartingLevel(startingLevel int) (*Manager, error) {
-	if startingLevel < 1 || startingLevel > len(definitions.LevelRegistry) {
-		return nil, fmt.Errorf("invalid starting level %d: must be between 1 and %d", startingLevel, len(definitions.LevelRegistry))
+	return NewManagerForPackWithStartingLevel(DefaultPack(), startingLevel)
+}
+
+// NewManagerForPackWithStartingLevel creates a new level manager for the given pack starting at a specific level
+func NewManagerForPackWithStartingLevel(pack *Pack, startingLevel int) (*Manager, error) {
+	if startingLevel < 1 || startingLevel > len(pack.Levels) {
+		return nil, fmt.Errorf("invalid starting level %d: must be between 1 and %d", startingLevel, len(pack.Levels))
 	}
 
 	return &Manager{
+		pack:         pack,
+		source:       pack,
+		name:         pack.Name,
 		currentLevel: startingLevel - 1, // Set to one before the desired level so NextLevel() returns the correct level
 	}, nil
 }
 
+// NewEndlessManager creates a level manager that never runs out of levels, synthesized by the curve.
+// The pack is the one the game goes back to once the run is over.
+func NewEndlessManager(pack *Pack, curve Curve, startingLevel int) (*Manager, error) {
+	if err := curve.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid endless curve: %w", err)
+	}
+	if startingLevel < 1 {
+		return nil, fmt.Errorf("invalid starting level %d: must be at least 1", startingLevel)
+	}
+
+	return &Manager{
+		pack:         pack,
+		source:       curve,
+		name:         EndlessName,
+		currentLevel: startingLevel - 1,
+	}, nil
+}
+
+// NewDailyManager creates a level manager for the daily challenge, whose levels are the same
+// for everyone playing them with the seed of the day
+func NewDailyManager(pack *Pack, startingLevel int) (*Manager, error) {
+	curve := DefaultCurve()
+	if err := curve.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid daily curve: %w", err)
+	}
+	if startingLevel < 1 || startingLevel > DailyLevels {
+		return nil, fmt.Errorf("invalid starting level %d: must be between 1 and %d", startingLevel, DailyLevels)
+	}
+
+	return &Manager{
+		pack:         pack,
+		source:       Daily{Curve: curve},
+		name:         DailyName,
+		currentLevel: startingLevel - 1,
+	}, nil
+}
+
+// Pack returns the level pack of the game. Endless runs and daily challenges do not play it, but go back to it.
+func (m *Manager) Pack() *Pack {
+	return m.pack
+}
+
+// Name returns the name the levels being played are saved and ranked under
+func (m *Manager) Name() string {
+	return m.name
+}
+
+// IsEndless returns true if the levels come from an endless curve instead of the pack
+func (m *Manager) IsEndless() bool {
+	_, endless := m.source.(Curve)
+	return endless
+}
+
 // NextLevel returns the next level configuration.
 // It returns the level, a boolean indicating if there is a next level,
 // and an error if there was a problem loading the level.
 func (m *Manager) NextLevel() (levelConfig definitions.LevelConfig, levelNumber int, found bool) {
 	m.currentLevel++
 
-	if m.currentLevel > len(definitions.LevelRegistry) {
-		levelConfig = definitions.EmptyLevelConfig
-		levelNumber = 0
-		found = false
-		return
+	levelConfig, found = m.source.Level(m.currentLevel)
+	if !found {
+		return definitions.EmptyLevelConfig, 0, false
 	}
-
-	levelConfig = definitions.LevelRegistry[m.currentLevel-1]()
-	levelNumber = m.currentLevel
-	found = true
-	return
+	return levelConfig, m.currentLevel, true
 }
 
 // GetCurrentLevel returns the current level configuration without advancing.
 // This is useful for restarting the current level.
 func (m *Manager) GetCurrentLevel() (levelConfig definitions.LevelConfig, levelNumber int, found bool) {
-	if m.currentLevel <= 0 || m.currentLevel > len(definitions.LevelRegistry) {
-		levelConfig = definitions.EmptyLevelConfig
-		levelNumber = 0
-		found = false
-		return
+	levelConfig, found = m.source.Level(m.currentLevel)
+	if !found {
+		return definitions.EmptyLevelConfig, 0, false
 	}
+	return levelConfig, m.currentLevel, true
+}
 
-	levelConfig = definitions.LevelRegistry[m.currentLevel-1]()
-	levelNumber = m.currentLevel
-	found = true
-	return
+// GoToLevel makes the given level the current one, as if it had just been returned by NextLevel
+func (m *Manager) GoToLevel(levelNumber int) error {
+	if !m.IsValidLevel(levelNumber) {
+		return fmt.Errorf("invalid level %d of %s", levelNumber, m.name)
+	}
+
+	m.currentLevel = levelNumber
+	return nil
 }
 
 // GetCurrentLevelNumber returns the current level number (1-based)
@@ -67,12 +144,19 @@ func (m *Manager) GetCurrentLevelNumber() int {
 	return m.currentLevel
 }
 
-// GetTotalLevels returns the total number of available levels
+// GetTotalLevels returns the total number of available levels, 0 for an endless run
 func (m *Manager) GetTotalLevels() int {
-	return len(definitions.LevelRegistry)
+	switch m.source.(type) {
+	case Curve:
+		return 0
+	case Daily:
+		return DailyLevels
+	}
+	return len(m.pack.Levels)
 }
 
 // IsValidLevel checks if a level number is valid
-func IsValidLevel(levelNumber int) bool {
-	return levelNumber >= 1 && levelNumber <= len(definitions.LevelRegistry)
+func (m *Manager) IsValidLevel(levelNumber int) bool {
+	_, found := m.source.Level(levelNumber)
+	return found
 }
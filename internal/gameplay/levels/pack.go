@@ -0,0 +1,268 @@
+package levels
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/juanancid/maze-adventure/internal/gameplay/levels/definitions"
+)
+
+// DefaultPackName is the name of the level pack compiled into the game
+const DefaultPackName = "default"
+
+// Pack is an ordered list of levels played one after the other
+type Pack struct {
+	Name   string                    `json:"name" yaml:"name"`
+	Levels []definitions.LevelConfig `json:"levels" yaml:"levels"`
+}
+
+// DefaultPack returns the built-in levels from definitions.LevelRegistry
+func DefaultPack() *Pack {
+	pack := &Pack{
+		Name:   DefaultPackName,
+		Levels: make([]definitions.LevelConfig, 0, len(definitions.LevelRegistry)),
+	}
+	for _, level := range definitions.LevelRegistry {
+		pack.Levels = append(pack.Levels, level())
+	}
+	return pack
+}
+
+// Level returns the configuration of a level of the pack (1-based), and false past the last one
+func (p *Pack) Level(levelNumber int) (definitions.LevelConfig, bool) {
+	if levelNumber < 1 || levelNumber > len(p.Levels) {
+		return definitions.EmptyLevelConfig, false
+	}
+	return p.Levels[levelNumber-1], true
+}
+
+// LoadPack reads and validates a level pack file. The format is picked from the
+// file extension: .json, .yaml or .yml.
+func LoadPack(path string) (*Pack, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read level pack: %w", err)
+	}
+	return ParsePack(path, data)
+}
+
+// ParsePack decodes and validates a level pack. The name is used to pick the format
+// and to prefix every error, which also carries the line it refers to.
+func ParsePack(name string, data []byte) (*Pack, error) {
+	var pack *Pack
+	var levelLines []int
+	var err error
+
+	switch ext := strings.ToLower(filepath.Ext(name)); ext {
+	case ".json":
+		pack, levelLines, err = parseJSONPack(data)
+	case ".yaml", ".yml":
+		pack, levelLines, err = parseYAMLPack(data)
+	default:
+		return nil, fmt.Errorf("%s: unsupported level pack format %q, expected .json, .yaml or .yml", name, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s:%w", name, err)
+	}
+
+	if pack.Name == "" {
+		pack.Name = strings.TrimSuffix(filepath.Base(name), filepath.Ext(name))
+	}
+
+	if err := pack.validate(name, levelLines); err != nil {
+		return nil, err
+	}
+
+	return pack, nil
+}
+
+// Validate checks every level of the pack and reports all the problems at once
+func (p *Pack) Validate() error {
+	return p.validate(p.Name, nil)
+}
+
+func (p *Pack) validate(name string, levelLines []int) error {
+	if len(p.Levels) == 0 {
+		return fmt.Errorf("%s: level pack has no levels", name)
+	}
+
+	var errs []error
+	for i, level := range p.Levels {
+		if err := level.Validate(); err != nil {
+			location := name
+			if i < len(levelLines) {
+				location = fmt.Sprintf("%s:%d", name, levelLines[i])
+			}
+			errs = append(errs, fmt.Errorf("%s: level %d: %w", location, i+1, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func parseJSONPack(data []byte) (*Pack, []int, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var pack Pack
+	if err := decoder.Decode(&pack); err != nil {
+		offset := decoder.InputOffset()
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntaxErr):
+			offset = syntaxErr.Offset
+		case errors.As(err, &typeErr):
+			offset = typeErr.Offset
+		default:
+			// Unknown field errors carry no offset, point at the last occurrence of the key read so far
+			if field, found := strings.CutPrefix(err.Error(), "json: unknown field "); found {
+				if index := bytes.LastIndex(data[:min(offset, int64(len(data)))], []byte(field)); index >= 0 {
+					offset = int64(index)
+				}
+			}
+		}
+		return nil, nil, fmt.Errorf("%d: %w", lineAt(data, offset), err)
+	}
+
+	levelLines, err := jsonLevelLines(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%d: %w", lineAt(data, int64(len(data))), err)
+	}
+
+	return &pack, levelLines, nil
+}
+
+// jsonLevelLines returns the line where every entry of the top-level "levels" array starts
+func jsonLevelLines(data []byte) ([]int, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil { // Opening brace
+		return nil, err
+	}
+
+	var lines []int
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		if key != "levels" {
+			if err := skipJSONValue(decoder); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if _, err := decoder.Token(); err != nil { // Opening bracket
+			return nil, err
+		}
+		for decoder.More() {
+			lines = append(lines, lineAt(data, nextJSONValue(data, decoder.InputOffset())))
+			if err := skipJSONValue(decoder); err != nil {
+				return nil, err
+			}
+		}
+		if _, err := decoder.Token(); err != nil { // Closing bracket
+			return nil, err
+		}
+	}
+
+	return lines, nil
+}
+
+func skipJSONValue(decoder *json.Decoder) error {
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		if delim, ok := token.(json.Delim); ok {
+			switch delim {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// nextJSONValue skips the separators the decoder has not consumed yet
+func nextJSONValue(data []byte, offset int64) int64 {
+	for offset < int64(len(data)) && strings.ContainsRune(" \t\r\n,", rune(data[offset])) {
+		offset++
+	}
+	return offset
+}
+
+func parseYAMLPack(data []byte) (*Pack, []int, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var pack Pack
+	if err := decoder.Decode(&pack); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, fmt.Errorf("1: empty level pack")
+		}
+		// yaml.v3 errors already carry the line, as in "yaml: line 4: ..."
+		return nil, nil, fmt.Errorf("%d: %w", yamlErrorLine(err), err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, nil, fmt.Errorf("%d: %w", yamlErrorLine(err), err)
+	}
+
+	return &pack, yamlLevelLines(&root), nil
+}
+
+// yamlLevelLines returns the line where every entry of the top-level "levels" sequence starts
+func yamlLevelLines(root *yaml.Node) []int {
+	if root.Kind != yaml.DocumentNode || len(root.Content) == 0 {
+		return nil
+	}
+
+	mapping := root.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != "levels" {
+			continue
+		}
+		var lines []int
+		for _, level := range mapping.Content[i+1].Content {
+			lines = append(lines, level.Line)
+		}
+		return lines
+	}
+
+	return nil
+}
+
+func yamlErrorLine(err error) int {
+	var line int
+	message := err.Error()
+	if index := strings.Index(message, "line "); index >= 0 {
+		fmt.Sscanf(message[index:], "line %d", &line)
+	}
+	return max(line, 1)
+}
+
+func lineAt(data []byte, offset int64) int {
+	offset = min(max(offset, 0), int64(len(data)))
+	return 1 + bytes.Count(data[:offset], []byte("\n"))
+}
@@ -0,0 +1,21 @@
+package levels
+
+import (
+	"github.com/juanancid/maze-adventure/internal/gameplay/levels/definitions"
+)
+
+// LevelSeed returns the seed used to build a level. A level with a fixed seed in its configuration
+// always uses it; otherwise the seed is derived from the run seed and the level number, so that
+// "seed X, level N" identifies a single level regardless of where the run started.
+func LevelSeed(levelConfig definitions.LevelConfig, runSeed int64, levelNumber int) int64 {
+	if levelConfig.Seed != 0 {
+		return levelConfig.Seed
+	}
+
+	// splitmix64 finalizer to spread consecutive level numbers over unrelated seeds
+	z := uint64(runSeed) + uint64(levelNumber)*0x9E3779B97F4A7C15
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	z ^= z >> 31
+	return int64(z)
+}
@@ -0,0 +1,30 @@
+package save
+
+import (
+	"slices"
+	"time"
+)
+
+// UnlockedAchievements returns the IDs of the achievements unlocked so far, sorted
+func (r *Records) UnlockedAchievements() []string {
+	ids := make([]string, 0, len(r.Achievements))
+	for id := range r.Achievements {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// UnlockAchievement records the achievement as unlocked at the given time. It returns false
+// if it was already unlocked, which keeps the time it was first unlocked at.
+func (r *Records) UnlockAchievement(id string, at time.Time) bool {
+	if _, unlocked := r.Achievements[id]; unlocked {
+		return false
+	}
+
+	if r.Achievements == nil {
+		r.Achievements = make(map[string]time.Time)
+	}
+	r.Achievements[id] = at
+	return true
+}
@@ -0,0 +1,52 @@
+package save
+
+// MaxDailyAttempts is how many days of daily challenge attempts are kept
+const MaxDailyAttempts = 30
+
+// DailyAttempt is the one scored attempt at the daily challenge of a day
+type DailyAttempt struct {
+	Date     string `json:"date"` // UTC day of the challenge, YYYY-MM-DD
+	Seed     int64  `json:"seed"` // Seed of the challenge, the same for everyone that day
+	Score    int    `json:"score"`
+	Level    int    `json:"level"`    // Last level reached
+	Finished bool   `json:"finished"` // Whether the run is over, in victory or game over
+}
+
+// DailyAttempt returns the attempt at the daily challenge of the given day, and false if it was not played
+func (r *Records) DailyAttempt(date string) (DailyAttempt, bool) {
+	for _, attempt := range r.Daily {
+		if attempt.Date == date {
+			return attempt, true
+		}
+	}
+	return DailyAttempt{}, false
+}
+
+// StartDaily uses up the attempt at the daily challenge of the day. It returns false
+// if the challenge was already attempted.
+func (r *Records) StartDaily(date string, seed int64) bool {
+	if _, attempted := r.DailyAttempt(date); attempted {
+		return false
+	}
+
+	r.Daily = append(r.Daily, DailyAttempt{Date: date, Seed: seed})
+	if len(r.Daily) > MaxDailyAttempts {
+		r.Daily = r.Daily[len(r.Daily)-MaxDailyAttempts:]
+	}
+	return true
+}
+
+// UpdateDaily records how far the attempt at the daily challenge with the given seed went.
+// Finished attempts do not change anymore.
+func (r *Records) UpdateDaily(seed int64, score, level int, finished bool) bool {
+	for i, attempt := range r.Daily {
+		if attempt.Seed != seed || attempt.Finished {
+			continue
+		}
+		r.Daily[i].Score = score
+		r.Daily[i].Level = level
+		r.Daily[i].Finished = finished
+		return true
+	}
+	return false
+}
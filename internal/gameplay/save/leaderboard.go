@@ -0,0 +1,87 @@
+package save
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juanancid/maze-adventure/internal/gameplay/session"
+)
+
+const (
+	// MaxRuns is how many runs the leaderboard of a pack keeps
+	MaxRuns = 10
+	// InitialsLength is how many letters a name on the leaderboard has
+	InitialsLength = 3
+)
+
+// RunRecord is a finished run on the leaderboard
+type RunRecord struct {
+	Initials string    `json:"initials"`
+	Score    int       `json:"score"`
+	Level    int       `json:"level"` // Last level reached
+	Victory  bool      `json:"victory"`
+	Seed     int64     `json:"seed"` // Seed of the run, to play it again
+	Date     time.Time `json:"date"`
+}
+
+// RunFromSession describes the run of the session, which ended with a victory or a game over
+func RunFromSession(gameSession *session.GameSession, victory bool) RunRecord {
+	return RunRecord{
+		Score:   gameSession.Score,
+		Level:   gameSession.CurrentLevel,
+		Victory: victory,
+		Seed:    gameSession.Seed,
+		Date:    time.Now(),
+	}
+}
+
+// Runs returns the leaderboard of the pack, best run first
+func (r *Records) Runs(pack string) []RunRecord {
+	packRecords, exists := r.Packs[pack]
+	if !exists {
+		return nil
+	}
+	return packRecords.Runs
+}
+
+// Qualifies returns true if a run with the given score would make it to the leaderboard of the pack
+func (r *Records) Qualifies(pack string, score int) bool {
+	runs := r.Runs(pack)
+	return len(runs) < MaxRuns || score > runs[len(runs)-1].Score
+}
+
+// AddRun puts the run on the leaderboard of the pack. It returns the position the run got,
+// starting at 0, or -1 if it did not make it. Runs with the same score keep the order they were added in.
+func (r *Records) AddRun(pack string, run RunRecord) int {
+	if !r.Qualifies(pack, run.Score) {
+		return -1
+	}
+
+	run.Initials = normalizeInitials(run.Initials)
+	packRecords := r.pack(pack)
+
+	position := len(packRecords.Runs)
+	for i, other := range packRecords.Runs {
+		if run.Score > other.Score {
+			position = i
+			break
+		}
+	}
+
+	packRecords.Runs = append(packRecords.Runs, RunRecord{})
+	copy(packRecords.Runs[position+1:], packRecords.Runs[position:])
+	packRecords.Runs[position] = run
+	if len(packRecords.Runs) > MaxRuns {
+		packRecords.Runs = packRecords.Runs[:MaxRuns]
+	}
+	return position
+}
+
+// normalizeInitials keeps names on the leaderboard to InitialsLength upper case letters
+func normalizeInitials(initials string) string {
+	initials = strings.ToUpper(initials)
+	if len(initials) > InitialsLength {
+		initials = initials[:InitialsLength]
+	}
+	return initials + strings.Repeat("-", InitialsLength-len(initials))
+}
@@ -0,0 +1,139 @@
+package save
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+)
+
+// RecordsVersion is the format of the records files written by this build
+const RecordsVersion = 1
+
+// LevelRecord is the best result of a level
+type LevelRecord struct {
+	BestScore int           `json:"bestScore"` // Points picked up in the level
+	BestTime  time.Duration `json:"bestTime"`  // Simulation time taken to finish the level
+}
+
+// PackRecords holds the records of the levels of a pack, keyed by level number, its leaderboard
+// and its fastest time-attack runs
+type PackRecords struct {
+	Levels     map[int]LevelRecord `json:"levels"`
+	Runs       []RunRecord         `json:"runs,omitempty"`       // Best runs first, at most MaxRuns
+	TimeAttack []TimeAttackRun     `json:"timeAttack,omitempty"` // Fastest time-attack runs first, at most MaxTimeAttackRuns
+}
+
+// Records are the best results of every level ever completed, the best runs, the daily
+// challenge attempts and the unlocked achievements, kept across runs
+type Records struct {
+	Version int                     `json:"version"`
+	Packs   map[string]*PackRecords `json:"packs"`
+	Daily   []DailyAttempt          `json:"daily,omitempty"` // Oldest first, at most MaxDailyAttempts
+
+	Achievements map[string]time.Time `json:"achievements,omitempty"` // When every unlocked achievement was unlocked, by ID
+}
+
+// NewRecords creates records where no level was completed yet
+func NewRecords() *Records {
+	return &Records{
+		Version: RecordsVersion,
+		Packs:   make(map[string]*PackRecords),
+	}
+}
+
+// Level returns the record of a level of the pack, and false if it was never completed
+func (r *Records) Level(pack string, level int) (LevelRecord, bool) {
+	packRecords, exists := r.Packs[pack]
+	if !exists {
+		return LevelRecord{}, false
+	}
+	record, completed := packRecords.Levels[level]
+	return record, completed
+}
+
+// IsUnlocked returns true if the level can be picked to start a run: the first level,
+// and every level following a completed one
+func (r *Records) IsUnlocked(pack string, level int) bool {
+	if level <= 1 {
+		return true
+	}
+	_, completed := r.Level(pack, level-1)
+	return completed
+}
+
+// Complete records a completed level. It returns true if it is a new best score or time.
+func (r *Records) Complete(pack string, level int, score int, playTime time.Duration) bool {
+	packRecords := r.pack(pack)
+
+	record, completed := packRecords.Levels[level]
+	if !completed {
+		packRecords.Levels[level] = LevelRecord{BestScore: score, BestTime: playTime}
+		return true
+	}
+
+	improved := false
+	if score > record.BestScore {
+		record.BestScore = score
+		improved = true
+	}
+	if playTime < record.BestTime {
+		record.BestTime = playTime
+		improved = true
+	}
+	packRecords.Levels[level] = record
+	return improved
+}
+
+// pack returns the records of the pack, created empty if there are none yet
+func (r *Records) pack(pack string) *PackRecords {
+	packRecords, exists := r.Packs[pack]
+	if !exists {
+		packRecords = &PackRecords{Levels: make(map[int]LevelRecord)}
+		r.Packs[pack] = packRecords
+	}
+	return packRecords
+}
+
+// LoadRecords reads the records. Missing records are empty, and records that cannot be used
+// are reported along with empty ones, so the game can go on and overwrite them.
+func (s *Store) LoadRecords() (*Records, error) {
+	path := s.recordsPath
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewRecords(), nil
+	}
+	if err != nil {
+		return NewRecords(), fmt.Errorf("failed to read records: %w", err)
+	}
+
+	records := NewRecords()
+	if err := json.Unmarshal(raw, records); err != nil {
+		return NewRecords(), fmt.Errorf("%w: %s: %v", ErrCorrupt, path, err)
+	}
+	if records.Version != RecordsVersion {
+		return NewRecords(), fmt.Errorf("%w: %s: version %d, expected %d", ErrVersion, path, records.Version, RecordsVersion)
+	}
+	for name, packRecords := range records.Packs {
+		if packRecords == nil {
+			records.Packs[name] = &PackRecords{Levels: make(map[int]LevelRecord)}
+		} else if packRecords.Levels == nil {
+			packRecords.Levels = make(map[int]LevelRecord)
+		}
+	}
+
+	return records, nil
+}
+
+// SaveRecords writes the records, replacing the previous ones
+func (s *Store) SaveRecords(records *Records) error {
+	records.Version = RecordsVersion
+	raw, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode records: %w", err)
+	}
+
+	return writeFile(s.recordsPath, raw)
+}
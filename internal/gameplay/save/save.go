@@ -0,0 +1,232 @@
+// Package save persists the progress of a run between game sessions. Progress is
+// saved at level boundaries, so a saved run resumes at the start of a level.
+package save
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/juanancid/maze-adventure/internal/gameplay/config"
+	"github.com/juanancid/maze-adventure/internal/gameplay/session"
+)
+
+// Version is the format of the save files written by this build. Files of any other
+// version are reported instead of loaded.
+const Version = 1
+
+const (
+	appDirName        = "maze-adventure"
+	saveFileName      = "save.json"
+	quicksaveFileName = "quicksave.json"
+	recordsFileName   = "records.json"
+)
+
+var (
+	// ErrNoSave is returned when there is no saved run
+	ErrNoSave = errors.New("no saved run")
+	// ErrCorrupt is returned when the save file cannot be decoded or holds impossible values
+	ErrCorrupt = errors.New("save file is corrupt")
+	// ErrVersion is returned when the save file was written by an incompatible version of the game
+	ErrVersion = errors.New("save file version is not supported")
+)
+
+// Data is the progress of a run at the start of a level
+type Data struct {
+	Version       int               `json:"version"`
+	Pack          string            `json:"pack"`           // Name of the level pack being played, or of the endless levels
+	Mode          config.Mode       `json:"mode,omitempty"` // Unset in saves from before modes, which were all campaigns
+	Level         int               `json:"level"`          // Level to resume at (1-based)
+	Score         int               `json:"score"`
+	CurrentHearts int               `json:"currentHearts"`
+	MaxHearts     int               `json:"maxHearts"`
+	Seed          int64             `json:"seed"` // Seed of the run, so resumed levels have the same layout
+	Stats         session.RunStats  `json:"stats"`
+	Difficulty    config.Difficulty `json:"difficulty"` // Unset in saves from before difficulties, which are resumed at the selected one
+	SavedAt       time.Time         `json:"savedAt"`
+	RunTime       time.Duration     `json:"runTime,omitempty"` // Time of the run so far, in time-attack runs
+	Splits        []time.Duration   `json:"splits,omitempty"`  // Run time at the end of every level, in time-attack runs
+}
+
+// FromSession captures the progress of the session, about to play the given level of the pack or endless run
+func FromSession(gameSession *session.GameSession, pack string, level int) Data {
+	return Data{
+		Version:       Version,
+		Pack:          pack,
+		Mode:          gameSession.Config.Mode,
+		Level:         level,
+		Score:         gameSession.Score,
+		CurrentHearts: gameSession.CurrentHearts,
+		MaxHearts:     gameSession.MaxHearts,
+		Seed:          gameSession.Seed,
+		Stats:         gameSession.Stats,
+		RunTime:       gameSession.RunTimer.Elapsed,
+		Splits:        slices.Clone(gameSession.RunTimer.Splits),
+		Difficulty:    gameSession.Config.Difficulty,
+		SavedAt:       time.Now(),
+	}
+}
+
+// ApplyTo restores the saved progress into a new session
+func (d Data) ApplyTo(gameSession *session.GameSession) {
+	gameSession.Score = d.Score
+	gameSession.CurrentHearts = d.CurrentHearts
+	gameSession.MaxHearts = d.MaxHearts
+	gameSession.Seed = d.Seed
+	gameSession.Stats = d.Stats
+	gameSession.RunTimer.Elapsed = d.RunTime
+	gameSession.RunTimer.Splits = slices.Clone(d.Splits)
+}
+
+// validate checks the values a hand-edited or truncated file could break
+func (d Data) validate() error {
+	switch {
+	case d.Pack == "":
+		return errors.New("missing level pack")
+	case d.Level < 1:
+		return fmt.Errorf("invalid level %d", d.Level)
+	case d.Score < 0:
+		return fmt.Errorf("invalid score %d", d.Score)
+	case d.MaxHearts < 1:
+		return fmt.Errorf("invalid max hearts %d", d.MaxHearts)
+	case d.CurrentHearts < 1 || d.CurrentHearts > d.MaxHearts:
+		return fmt.Errorf("invalid hearts %d of %d", d.CurrentHearts, d.MaxHearts)
+	}
+	return nil
+}
+
+// Store reads and writes the saved run in a single file, and the quicksave and records next to it
+type Store struct {
+	path          string
+	quicksavePath string
+	recordsPath   string
+}
+
+// NewStore creates a store that keeps the saved run in the given file
+func NewStore(path string) *Store {
+	return &Store{
+		path:          path,
+		quicksavePath: filepath.Join(filepath.Dir(path), quicksaveFileName),
+		recordsPath:   filepath.Join(filepath.Dir(path), recordsFileName),
+	}
+}
+
+// NewDefaultStore creates a store in the maze-adventure directory of the user config directory
+func NewDefaultStore() (*Store, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to locate the user config directory: %w", err)
+	}
+	return NewStore(filepath.Join(dir, appDirName, saveFileName)), nil
+}
+
+// Path returns the file the store reads and writes
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load reads the saved run. It returns ErrNoSave if there is none, and an error
+// wrapping ErrCorrupt or ErrVersion if the file cannot be used.
+func (s *Store) Load() (Data, error) {
+	raw, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Data{}, ErrNoSave
+	}
+	if err != nil {
+		return Data{}, fmt.Errorf("failed to read save file: %w", err)
+	}
+
+	// The version is checked first, other versions may not decode into the current format
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return Data{}, fmt.Errorf("%w: %s: %v", ErrCorrupt, s.path, err)
+	}
+	if header.Version != Version {
+		return Data{}, fmt.Errorf("%w: %s: version %d, expected %d", ErrVersion, s.path, header.Version, Version)
+	}
+
+	var data Data
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return Data{}, fmt.Errorf("%w: %s: %v", ErrCorrupt, s.path, err)
+	}
+	if err := data.validate(); err != nil {
+		return Data{}, fmt.Errorf("%w: %s: %v", ErrCorrupt, s.path, err)
+	}
+
+	return data, nil
+}
+
+// Save writes the run, replacing the previous one. A crash never leaves a half-written save.
+func (s *Store) Save(data Data) error {
+	data.Version = Version
+	raw, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode save: %w", err)
+	}
+
+	return writeFile(s.path, raw)
+}
+
+// SaveQuick writes a snapshot of the running level, replacing the previous one
+func (s *Store) SaveQuick(snapshot []byte) error {
+	return writeFile(s.quicksavePath, snapshot)
+}
+
+// LoadQuick reads the last snapshot written by SaveQuick. It returns ErrNoSave if there is none.
+func (s *Store) LoadQuick() ([]byte, error) {
+	snapshot, err := os.ReadFile(s.quicksavePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNoSave
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read quicksave: %w", err)
+	}
+	return snapshot, nil
+}
+
+// Clear deletes the saved run, if any
+func (s *Store) Clear() error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete save file: %w", err)
+	}
+	return nil
+}
+
+// writeFile writes to a temporary file first and renames it over the old one,
+// so a crash never leaves a half-written file
+func writeFile(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create save directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create save file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // Fails harmlessly once renamed
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write save file: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write save file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write save file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace save file: %w", err)
+	}
+
+	return nil
+}
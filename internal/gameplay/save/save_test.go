@@ -0,0 +1,201 @@
+package save
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/juanancid/maze-adventure/internal/gameplay/config"
+	"github.com/juanancid/maze-adventure/internal/gameplay/session"
+)
+
+func newTestStore(t *testing.T) *Store {
+	return NewStore(filepath.Join(t.TempDir(), appDirName, saveFileName))
+}
+
+func testData() Data {
+	return Data{
+		Pack:          "classic",
+		Mode:          config.ModeTimeAttack,
+		Level:         4,
+		Score:         1250,
+		CurrentHearts: 2,
+		MaxHearts:     3,
+		Seed:          -8312,
+		Stats: session.RunStats{
+			LevelsCompleted:    3,
+			CollectiblesPicked: 11,
+			HeartsLost:         1,
+			PlayTime:           95 * time.Second,
+		},
+		Difficulty: config.Hard(),
+		SavedAt:    time.Date(2026, 3, 14, 15, 9, 26, 0, time.UTC),
+		RunTime:    97 * time.Second,
+		Splits:     []time.Duration{20 * time.Second, 51 * time.Second, 97 * time.Second},
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	store := newTestStore(t)
+	data := testData()
+
+	if err := store.Save(data); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	data.Version = Version
+	if !reflect.DeepEqual(loaded, data) {
+		t.Errorf("Load = %+v, want %+v", loaded, data)
+	}
+
+	// Saving again replaces the run and leaves no temporary file behind
+	data.Level = 5
+	if err := store.Save(data); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if loaded, err := store.Load(); err != nil || loaded.Level != 5 {
+		t.Errorf("Load after a second save = level %d, %v, want level 5", loaded.Level, err)
+	}
+	entries, err := os.ReadDir(filepath.Dir(store.Path()))
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("save directory holds %d files, want only the save", len(entries))
+	}
+}
+
+func TestLoadWithoutSave(t *testing.T) {
+	store := newTestStore(t)
+
+	if _, err := store.Load(); !errors.Is(err, ErrNoSave) {
+		t.Errorf("Load error = %v, want %v", err, ErrNoSave)
+	}
+
+	// Clearing the run brings back the missing save
+	if err := store.Save(testData()); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := store.Clear(); err != nil {
+		t.Fatalf("Clear: %v", err)
+	}
+	if _, err := store.Load(); !errors.Is(err, ErrNoSave) {
+		t.Errorf("Load error after Clear = %v, want %v", err, ErrNoSave)
+	}
+	if err := store.Clear(); err != nil {
+		t.Errorf("Clear without a save: %v", err)
+	}
+}
+
+func TestLoadRejectsUnusableFiles(t *testing.T) {
+	valid := func(t *testing.T) string {
+		store := newTestStore(t)
+		if err := store.Save(testData()); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		raw, err := os.ReadFile(store.Path())
+		if err != nil {
+			t.Fatalf("ReadFile: %v", err)
+		}
+		return string(raw)
+	}
+
+	tests := []struct {
+		name string
+		edit func(raw string) string
+		want error
+	}{
+		{
+			name: "truncated",
+			edit: func(raw string) string { return raw[:len(raw)/2] },
+			want: ErrCorrupt,
+		},
+		{
+			name: "empty",
+			edit: func(string) string { return "" },
+			want: ErrCorrupt,
+		},
+		{
+			name: "version 0",
+			edit: func(raw string) string { return strings.Replace(raw, `"version": 1`, `"version": 0`, 1) },
+			want: ErrVersion,
+		},
+		{
+			name: "newer version",
+			edit: func(raw string) string { return strings.Replace(raw, `"version": 1`, `"version": 9`, 1) },
+			want: ErrVersion,
+		},
+		{
+			name: "more hearts than the maximum",
+			edit: func(raw string) string { return strings.Replace(raw, `"currentHearts": 2`, `"currentHearts": 4`, 1) },
+			want: ErrCorrupt,
+		},
+		{
+			name: "no hearts left",
+			edit: func(raw string) string { return strings.Replace(raw, `"currentHearts": 2`, `"currentHearts": 0`, 1) },
+			want: ErrCorrupt,
+		},
+		{
+			name: "level 0",
+			edit: func(raw string) string { return strings.Replace(raw, `"level": 4`, `"level": 0`, 1) },
+			want: ErrCorrupt,
+		},
+		{
+			name: "missing pack",
+			edit: func(raw string) string { return strings.Replace(raw, `"pack": "classic"`, `"pack": ""`, 1) },
+			want: ErrCorrupt,
+		},
+		{
+			name: "wrong type",
+			edit: func(raw string) string { return strings.Replace(raw, `"score": 1250`, `"score": "lots"`, 1) },
+			want: ErrCorrupt,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			raw := valid(t)
+			edited := tt.edit(raw)
+			if edited == raw {
+				t.Fatal("the edit did not change the file")
+			}
+
+			store := newTestStore(t)
+			if err := os.MkdirAll(filepath.Dir(store.Path()), 0o755); err != nil {
+				t.Fatalf("MkdirAll: %v", err)
+			}
+			if err := os.WriteFile(store.Path(), []byte(edited), 0o644); err != nil {
+				t.Fatalf("WriteFile: %v", err)
+			}
+
+			if _, err := store.Load(); !errors.Is(err, tt.want) {
+				t.Errorf("Load error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuicksaveRoundTrip(t *testing.T) {
+	store := newTestStore(t)
+
+	if _, err := store.LoadQuick(); !errors.Is(err, ErrNoSave) {
+		t.Errorf("LoadQuick error = %v, want %v", err, ErrNoSave)
+	}
+
+	snapshot := []byte(`{"version":2}`)
+	if err := store.SaveQuick(snapshot); err != nil {
+		t.Fatalf("SaveQuick: %v", err)
+	}
+	loaded, err := store.LoadQuick()
+	if err != nil || string(loaded) != string(snapshot) {
+		t.Errorf("LoadQuick = %q, %v, want %q", loaded, err, snapshot)
+	}
+}
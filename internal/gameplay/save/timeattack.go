@@ -0,0 +1,72 @@
+package save
+
+import (
+	"slices"
+	"time"
+
+	"github.com/juanancid/maze-adventure/internal/gameplay/session"
+)
+
+// MaxTimeAttackRuns is how many time-attack runs of a pack are kept
+const MaxTimeAttackRuns = 10
+
+// TimeAttackRun is a time-attack run that got through every level of a pack
+type TimeAttackRun struct {
+	Time   time.Duration   `json:"time"`
+	Splits []time.Duration `json:"splits"` // Run time at the end of every level
+	Seed   int64           `json:"seed"`   // Seed of the run, to play it again
+	Date   time.Time       `json:"date"`
+}
+
+// TimeAttackRunFromSession describes the time-attack run of the session, which got through every level
+func TimeAttackRunFromSession(gameSession *session.GameSession) TimeAttackRun {
+	return TimeAttackRun{
+		Time:   gameSession.RunTimer.Elapsed,
+		Splits: slices.Clone(gameSession.RunTimer.Splits),
+		Seed:   gameSession.Seed,
+		Date:   time.Now(),
+	}
+}
+
+// TimeAttackRuns returns the best time-attack runs of the pack, fastest first
+func (r *Records) TimeAttackRuns(pack string) []TimeAttackRun {
+	packRecords, exists := r.Packs[pack]
+	if !exists {
+		return nil
+	}
+	return packRecords.TimeAttack
+}
+
+// BestSplits returns the splits of the fastest time-attack run of the pack, and nil if there is none
+func (r *Records) BestSplits(pack string) []time.Duration {
+	runs := r.TimeAttackRuns(pack)
+	if len(runs) == 0 {
+		return nil
+	}
+	return runs[0].Splits
+}
+
+// AddTimeAttackRun keeps the run if it is one of the fastest of the pack. It returns the position
+// the run got, starting at 0 for a new personal best, or -1 if it did not make it.
+func (r *Records) AddTimeAttackRun(pack string, run TimeAttackRun) int {
+	runs := r.TimeAttackRuns(pack)
+	if len(runs) >= MaxTimeAttackRuns && run.Time >= runs[len(runs)-1].Time {
+		return -1
+	}
+
+	// Runs with the same time keep the order they were added in
+	packRecords := r.pack(pack)
+	position := len(packRecords.TimeAttack)
+	for i, other := range packRecords.TimeAttack {
+		if run.Time < other.Time {
+			position = i
+			break
+		}
+	}
+
+	packRecords.TimeAttack = slices.Insert(packRecords.TimeAttack, position, run)
+	if len(packRecords.TimeAttack) > MaxTimeAttackRuns {
+		packRecords.TimeAttack = packRecords.TimeAttack[:MaxTimeAttackRuns]
+	}
+	return position
+}
@@ -0,0 +1,77 @@
+package session
+
+import "time"
+
+const (
+	// TimeBonusPerSecond is the score given for every whole second left on the level timer
+	TimeBonusPerSecond = 10
+	// NoDamageBonus is the score given for completing a level without losing a heart
+	NoDamageBonus = 100
+)
+
+// LevelStats sums up how the current level is going, they start over with every level
+type LevelStats struct {
+	CollectiblesPicked int `json:"collectiblesPicked"`
+	CollectiblesTotal  int `json:"collectiblesTotal"` // Collectibles worth points when the level started
+	HeartsLost         int `json:"heartsLost"`
+	Freezes            int `json:"freezes"`
+	Hits               int `json:"hits"` // Hits that cost run time instead of hearts, in time-attack runs
+}
+
+// StartLevel resets the level stats for a level with the given number of collectibles
+func (g *GameSession) StartLevel(collectibles int) {
+	g.Level = LevelStats{CollectiblesTotal: collectibles}
+}
+
+// Bonus is score earned on top of the collectibles when a level is completed
+type Bonus struct {
+	Label  string
+	Points int
+}
+
+// LevelSummary is how the player did in a completed level
+type LevelSummary struct {
+	Level          int
+	Stats          LevelStats
+	TimerEnabled   bool
+	TimerRemaining float64 // Seconds left on the timer
+	PlayTime       time.Duration
+	Split          *Split // Split taken at the end of the level in time-attack runs, nil otherwise
+	Bonuses        []Bonus
+}
+
+// BonusPoints returns the score of every bonus earned
+func (s LevelSummary) BonusPoints() int {
+	total := 0
+	for _, bonus := range s.Bonuses {
+		total += bonus.Points
+	}
+	return total
+}
+
+// CompleteLevel sums up the current level, played for the given time, and adds the bonuses earned to the score
+func (g *GameSession) CompleteLevel(playTime time.Duration) LevelSummary {
+	summary := LevelSummary{
+		Level:          g.CurrentLevel,
+		Stats:          g.Level,
+		TimerEnabled:   g.TimerEnabled,
+		TimerRemaining: g.TimerRemaining,
+		PlayTime:       playTime,
+	}
+	if g.RunTimer.Enabled {
+		split := g.TakeSplit()
+		summary.Split = &split
+	}
+
+	if g.TimerEnabled {
+		if seconds := int(g.TimerRemaining); seconds > 0 {
+			summary.Bonuses = append(summary.Bonuses, Bonus{Label: "TIME BONUS", Points: seconds * TimeBonusPerSecond})
+		}
+	}
+	if g.Level.HeartsLost == 0 && g.Level.Hits == 0 {
+		summary.Bonuses = append(summary.Bonuses, Bonus{Label: "NO DAMAGE", Points: NoDamageBonus})
+	}
+
+	g.Score += summary.BonusPoints()
+	return summary
+}
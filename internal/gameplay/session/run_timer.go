@@ -0,0 +1,93 @@
+package session
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeAttackDamagePenalty is the run time added for every heart a hit would take in a
+// time-attack run, where the player has no hearts to lose
+const TimeAttackDamagePenalty = 2 * time.Second
+
+// RunTimer times a time-attack run: it counts up from the start of the run and takes a split
+// at the end of every level, to compare with the splits of the personal best
+type RunTimer struct {
+	Enabled bool
+	Elapsed time.Duration   // Run time so far
+	Splits  []time.Duration // Run time at the end of every completed level
+	Best    []time.Duration // Splits of the personal best, empty if there is none
+}
+
+// Split is the run time at the end of a level, compared with the personal best
+type Split struct {
+	Time    time.Duration
+	Delta   time.Duration // Time minus the split of the personal best, negative when ahead
+	HasBest bool          // Whether the personal best has a split for the level to compare with
+}
+
+// UpdateRunTimer adds the given time to the run timer
+func (g *GameSession) UpdateRunTimer(delta time.Duration) {
+	if g.RunTimer.Enabled {
+		g.RunTimer.Elapsed += delta
+	}
+}
+
+// TakeSplit records the run time at the end of the current level
+func (g *GameSession) TakeSplit() Split {
+	g.RunTimer.Splits = append(g.RunTimer.Splits, g.RunTimer.Elapsed)
+	return g.RunTimer.split(len(g.RunTimer.Splits)-1, g.RunTimer.Elapsed)
+}
+
+// SplitComparison returns how the run compares with the personal best, and false if the
+// personal best has no split to compare with yet. Once the run time goes past the personal
+// best split of the level being played, that split is the comparison; until then, the last
+// split taken is.
+func (g *GameSession) SplitComparison() (Split, bool) {
+	timer := g.RunTimer
+	current := len(timer.Splits)
+	if current < len(timer.Best) && timer.Elapsed > timer.Best[current] {
+		return timer.split(current, timer.Elapsed), true
+	}
+	if current == 0 || current > len(timer.Best) {
+		return Split{}, false
+	}
+	return timer.split(current-1, timer.Splits[current-1]), true
+}
+
+func (t RunTimer) split(index int, runTime time.Duration) Split {
+	split := Split{Time: runTime}
+	if index < len(t.Best) {
+		split.Delta = runTime - t.Best[index]
+		split.HasBest = true
+	}
+	return split
+}
+
+// GetRunTimerDisplayTime returns the run timer in MM:SS.cc format
+func (g *GameSession) GetRunTimerDisplayTime() string {
+	if !g.RunTimer.Enabled {
+		return ""
+	}
+	return FormatRunTime(g.RunTimer.Elapsed)
+}
+
+// FormatRunTime writes a run time with hundredths of a second, as MM:SS.cc
+func FormatRunTime(d time.Duration) string {
+	centiseconds := int(d / (10 * time.Millisecond))
+	return fmt.Sprintf("%02d:%02d.%02d", centiseconds/6000, centiseconds/100%60, centiseconds%100)
+}
+
+// FormatSplitDelta writes the difference with a personal best split, signed, as +S.cc
+func FormatSplitDelta(d time.Duration) string {
+	sign := "+"
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	centiseconds := int(d / (10 * time.Millisecond))
+	if centiseconds >= 6000 {
+		return fmt.Sprintf("%s%d:%02d.%02d", sign, centiseconds/6000, centiseconds/100%60, centiseconds%100)
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, centiseconds/100, centiseconds%100)
+}
@@ -2,58 +2,79 @@ package session
 
 import (
 	"fmt"
+	"math"
 	"time"
 
+	"github.com/juanancid/maze-adventure/internal/engine/clock"
 	"github.com/juanancid/maze-adventure/internal/gameplay/config"
 )
 
-const (
-	// DefaultFreezeDuration is the default time a player is frozen when entering a freezing cell
-	DefaultFreezeDuration = 2500 * time.Millisecond // 2.5 seconds
-	// DefaultDamageCooldown is the default time before a player can take damage again
-	DefaultDamageCooldown = 1500 * time.Millisecond // 1.5 seconds
-)
+// RunStats sums up how a run went, shown once it is over
+type RunStats struct {
+	LevelsCompleted    int           `json:"levelsCompleted"`
+	CollectiblesPicked int           `json:"collectiblesPicked"`
+	HeartsLost         int           `json:"heartsLost"`
+	Retries            int           `json:"retries"`  // Levels played again after a game over
+	PlayTime           time.Duration `json:"playTime"` // Simulation time spent playing levels
+}
 
 type GameSession struct {
 	Score         int
 	CurrentLevel  int
 	MaxHearts     int
 	CurrentHearts int
-	Config        config.GameConfig
+	Config        config.GameConfig // Configuration of the run, its difficulty is always set
+	Seed          int64             // Seed of the run, every level seed is derived from it
+	Clock         clock.Clock       // Simulation clock every timed effect is measured with
+	Stats         RunStats
+	Level         LevelStats // Stats of the level being played
 	// Timer fields
 	TimerEnabled   bool    // Whether the current level has a timer
 	TimerRemaining float64 // Remaining time in seconds (float for smooth countdown)
 	TimerTotal     int     // Total time for the level in seconds
+	// Run timer fields
+	RunTimer RunTimer // Time of the whole run, counting up in time-attack runs
 	// Freeze fields
 	IsFrozen          bool          // Whether the player is currently frozen
-	FreezeStartTime   time.Time     // When the freeze effect started
+	FreezeStartTime   time.Duration // Simulation time when the freeze effect started
 	FreezeDuration    time.Duration // How long the freeze effect lasts
 	LastFreezeCellCol int           // Last cell where freeze was applied (to prevent re-triggering)
 	LastFreezeCellRow int           // Last cell where freeze was applied (to prevent re-triggering)
 	CurrentCellCol    int           // Current cell column position
 	CurrentCellRow    int           // Current cell row position
 	// Damage cooldown fields
-	LastDamageTime    time.Time     // When the player last took damage
+	LastDamageTime    time.Duration // Simulation time when the player last took damage
+	HasTakenDamage    bool          // Whether LastDamageTime is set
 	DamageCooldown    time.Duration // How long to wait before taking damage again
 	LastDamageCellCol int           // Last cell where damage was applied (to prevent re-triggering)
 	LastDamageCellRow int           // Last cell where damage was applied (to prevent re-triggering)
 }
 
-// NewGameSession creates a new game session with the specified configuration
-func NewGameSession(config config.GameConfig) *GameSession {
+// NewGameSession creates a new game session with the specified configuration, timed by the given clock.
+// The hearts and the damage cooldown come from the difficulty, Normal if none is set.
+func NewGameSession(gameConfig config.GameConfig, clock clock.Clock) *GameSession {
+	seed := gameConfig.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	gameConfig.Difficulty = gameConfig.Difficulty.OrNormal()
+
 	return &GameSession{
 		Score:             0,
 		CurrentLevel:      0,
-		MaxHearts:         config.StartingHearts,
-		CurrentHearts:     config.StartingHearts,
-		Config:            config,
+		MaxHearts:         gameConfig.Difficulty.StartingHearts,
+		CurrentHearts:     gameConfig.Difficulty.StartingHearts,
+		Config:            gameConfig,
+		Seed:              seed,
+		Clock:             clock,
 		LastFreezeCellCol: -1, // -1 indicates no previous freeze cell
 		LastFreezeCellRow: -1,
 		CurrentCellCol:    -1, // -1 indicates uninitialized
 		CurrentCellRow:    -1,
-		DamageCooldown:    DefaultDamageCooldown,
+		DamageCooldown:    gameConfig.Difficulty.DamageCooldown,
 		LastDamageCellCol: -1, // -1 indicates no previous damage cell
 		LastDamageCellRow: -1,
+		RunTimer:          RunTimer{Enabled: gameConfig.Mode == config.ModeTimeAttack},
 	}
 }
 
@@ -61,6 +82,8 @@ func NewGameSession(config config.GameConfig) *GameSession {
 func (g *GameSession) TakeDamage() {
 	if g.CurrentHearts > 0 {
 		g.CurrentHearts--
+		g.Stats.HeartsLost++
+		g.Level.HeartsLost++
 	}
 }
 
@@ -76,9 +99,11 @@ func (g *GameSession) IsAlive() bool {
 	return g.CurrentHearts > 0
 }
 
-// SetTimer initializes the timer for a level
+// SetTimer initializes the timer for a level, scaled by the difficulty. Time-attack runs
+// have no level timers, only the run timer.
 func (g *GameSession) SetTimer(timerSeconds int) {
-	if timerSeconds > 0 {
+	if timerSeconds > 0 && !g.RunTimer.Enabled {
+		timerSeconds = max(int(math.Round(float64(timerSeconds)*g.Config.Difficulty.TimerScale)), 1)
 		g.TimerEnabled = true
 		g.TimerTotal = timerSeconds
 		g.TimerRemaining = float64(timerSeconds)
@@ -132,7 +157,8 @@ func (g *GameSession) HasCellChanged(col, row int) bool {
 // StartFreeze immobilizes the player for the specified duration
 func (g *GameSession) StartFreeze(duration time.Duration) {
 	g.IsFrozen = true
-	g.FreezeStartTime = time.Now()
+	g.Level.Freezes++
+	g.FreezeStartTime = g.Clock.Now()
 	g.FreezeDuration = duration
 	g.LastFreezeCellCol = g.CurrentCellCol
 	g.LastFreezeCellRow = g.CurrentCellRow
@@ -140,7 +166,7 @@ func (g *GameSession) StartFreeze(duration time.Duration) {
 
 // UpdateFreezeState checks if the freeze duration has expired and updates state accordingly
 func (g *GameSession) UpdateFreezeState() {
-	if g.IsFrozen && time.Since(g.FreezeStartTime) >= g.FreezeDuration {
+	if g.IsFrozen && g.Clock.Now()-g.FreezeStartTime >= g.FreezeDuration {
 		g.IsFrozen = false
 	}
 }
@@ -159,17 +185,30 @@ func (g *GameSession) IsImmobilized() bool {
 
 // CanApplyDamageEffect checks if damage can be applied (not in cooldown and not in the same cell as last damage)
 func (g *GameSession) CanApplyDamageEffect() bool {
+	if !g.HasTakenDamage {
+		return true
+	}
+
 	// Check if enough time has passed since last damage
-	timeSinceLastDamage := time.Since(g.LastDamageTime)
+	timeSinceLastDamage := g.Clock.Now() - g.LastDamageTime
 	cooldownExpired := timeSinceLastDamage >= g.DamageCooldown
 
 	return cooldownExpired
 }
 
-// ApplyDamageWithCooldown applies damage and sets the cooldown
-func (g *GameSession) ApplyDamageWithCooldown() {
-	g.TakeDamage()
-	g.LastDamageTime = time.Now()
+// ApplyDamageWithCooldown takes the given number of hearts and sets the cooldown.
+// Time-attack runs lose time instead of hearts.
+func (g *GameSession) ApplyDamageWithCooldown(amount int) {
+	if g.RunTimer.Enabled {
+		g.RunTimer.Elapsed += time.Duration(amount) * TimeAttackDamagePenalty
+		g.Level.Hits++
+	} else {
+		for range amount {
+			g.TakeDamage()
+		}
+	}
+	g.LastDamageTime = g.Clock.Now()
+	g.HasTakenDamage = true
 	g.LastDamageCellCol = g.CurrentCellCol
 	g.LastDamageCellRow = g.CurrentCellRow
 }
@@ -0,0 +1,145 @@
+package session
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/juanancid/maze-adventure/internal/engine/clock"
+	"github.com/juanancid/maze-adventure/internal/gameplay/config"
+)
+
+// tick divides every duration in the tests, so advancing by a duration takes a whole number of ticks
+const tick = 10 * time.Millisecond
+
+func newTestSession(mode config.Mode) (*GameSession, *clock.Fake) {
+	fake := clock.NewFake()
+	return NewGameSession(config.GameConfig{Mode: mode, Difficulty: config.Normal(), Seed: 1}, fake), fake
+}
+
+// advance moves the clock tick by tick for the given duration, updating the session timers
+// the way the Timer updater does
+func advance(g *GameSession, fake *clock.Fake, d time.Duration) {
+	for elapsed := time.Duration(0); elapsed < d; elapsed += tick {
+		fake.Advance(tick)
+		g.UpdateRunTimer(fake.Delta())
+		g.UpdateTimer(fake.Delta().Seconds())
+		g.UpdateFreezeState()
+	}
+}
+
+func TestFreezeLastsItsDuration(t *testing.T) {
+	g, fake := newTestSession(config.ModeCampaign)
+	advance(g, fake, 10*time.Second)
+	g.StartFreeze(time.Second)
+
+	advance(g, fake, time.Second-tick)
+	if !g.IsImmobilized() {
+		t.Fatal("freeze ended before its duration")
+	}
+	advance(g, fake, tick)
+	if g.IsImmobilized() {
+		t.Error("freeze still on after its duration")
+	}
+	if g.Level.Freezes != 1 {
+		t.Errorf("level freezes = %d, want 1", g.Level.Freezes)
+	}
+}
+
+func TestFreezeWaitsWhileTheClockDoesNotAdvance(t *testing.T) {
+	g, fake := newTestSession(config.ModeCampaign)
+	g.StartFreeze(time.Second)
+
+	// A paused clock does not advance: however many updates run, the freeze goes on
+	for range 600 {
+		fake.Advance(0)
+		g.UpdateFreezeState()
+	}
+	if !g.IsImmobilized() {
+		t.Error("freeze ended without simulation time passing")
+	}
+}
+
+func TestDamageCooldown(t *testing.T) {
+	g, fake := newTestSession(config.ModeCampaign)
+	if !g.CanApplyDamageEffect() {
+		t.Fatal("a new session cannot take damage")
+	}
+
+	g.ApplyDamageWithCooldown(1)
+	if g.CurrentHearts != g.MaxHearts-1 || g.Level.HeartsLost != 1 {
+		t.Errorf("hearts=%d level hearts lost=%d, want %d and 1", g.CurrentHearts, g.Level.HeartsLost, g.MaxHearts-1)
+	}
+
+	advance(g, fake, g.DamageCooldown-tick)
+	if g.CanApplyDamageEffect() {
+		t.Error("damage can be applied during the cooldown")
+	}
+	advance(g, fake, tick)
+	if !g.CanApplyDamageEffect() {
+		t.Error("damage cannot be applied after the cooldown")
+	}
+}
+
+func TestLevelTimerExpires(t *testing.T) {
+	g, fake := newTestSession(config.ModeCampaign)
+	g.SetTimer(2)
+
+	advance(g, fake, time.Second)
+	if g.IsTimerExpired() || math.Abs(g.TimerRemaining-1) > 1e-9 {
+		t.Errorf("timer has %vs left after 1s of 2s, expired=%v", g.TimerRemaining, g.IsTimerExpired())
+	}
+	advance(g, fake, time.Second)
+	if !g.IsTimerExpired() {
+		t.Error("timer not expired after its whole duration")
+	}
+}
+
+func TestRunTimerCountsUpInTimeAttack(t *testing.T) {
+	g, fake := newTestSession(config.ModeTimeAttack)
+	g.SetTimer(30)
+	if g.TimerEnabled {
+		t.Error("time-attack run has a level timer")
+	}
+
+	advance(g, fake, 3*time.Second)
+	g.ApplyDamageWithCooldown(1)
+	if want := 3*time.Second + TimeAttackDamagePenalty; g.RunTimer.Elapsed != want {
+		t.Errorf("run time = %v, want %v", g.RunTimer.Elapsed, want)
+	}
+	if g.CurrentHearts != g.MaxHearts {
+		t.Errorf("time-attack hit took hearts: %d of %d left", g.CurrentHearts, g.MaxHearts)
+	}
+
+	for _, bonus := range g.CompleteLevel(3 * time.Second).Bonuses {
+		if bonus.Label == "NO DAMAGE" {
+			t.Error("no damage bonus awarded after a time-attack hit")
+		}
+	}
+}
+
+func TestRestoreKeepsFreezeAndCooldownLeft(t *testing.T) {
+	g, fake := newTestSession(config.ModeCampaign)
+	advance(g, fake, 5*time.Second)
+	g.StartFreeze(2 * time.Second)
+	g.ApplyDamageWithCooldown(1)
+	advance(g, fake, 500*time.Millisecond)
+	state := g.State()
+
+	// The restored session runs on a clock at another time, like after a quickload
+	restored, restoredClock := newTestSession(config.ModeCampaign)
+	restoredClock.Advance(time.Minute)
+	restored.Restore(state)
+
+	advance(restored, restoredClock, 1500*time.Millisecond-tick)
+	if !restored.IsImmobilized() {
+		t.Error("restored freeze ended early")
+	}
+	advance(restored, restoredClock, tick)
+	if restored.IsImmobilized() {
+		t.Error("restored freeze lasted longer than what was left")
+	}
+	if !restored.CanApplyDamageEffect() {
+		t.Error("restored damage cooldown lasted longer than what was left")
+	}
+}
@@ -0,0 +1,120 @@
+package session
+
+import (
+	"slices"
+	"time"
+)
+
+// State holds the fields of a session that change while playing, so a running level can be
+// saved and restored. Effects started at a point in time are kept as the time elapsed since
+// then, so they can be restored on a clock that does not show the same time.
+type State struct {
+	Time time.Duration `json:"time"` // Simulation time when the state was captured, to set the clock back to
+
+	Score         int        `json:"score"`
+	CurrentLevel  int        `json:"currentLevel"`
+	MaxHearts     int        `json:"maxHearts"`
+	CurrentHearts int        `json:"currentHearts"`
+	Seed          int64      `json:"seed"`
+	Stats         RunStats   `json:"stats"`
+	Level         LevelStats `json:"level"`
+
+	TimerEnabled   bool    `json:"timerEnabled"`
+	TimerRemaining float64 `json:"timerRemaining"`
+	TimerTotal     int     `json:"timerTotal"`
+
+	RunTime time.Duration   `json:"runTime,omitempty"`
+	Splits  []time.Duration `json:"splits,omitempty"`
+
+	IsFrozen          bool          `json:"isFrozen"`
+	FreezeElapsed     time.Duration `json:"freezeElapsed"` // Time since the freeze started
+	FreezeDuration    time.Duration `json:"freezeDuration"`
+	LastFreezeCellCol int           `json:"lastFreezeCellCol"`
+	LastFreezeCellRow int           `json:"lastFreezeCellRow"`
+	CurrentCellCol    int           `json:"currentCellCol"`
+	CurrentCellRow    int           `json:"currentCellRow"`
+
+	Damaged           bool          `json:"damaged"`        // Whether the player was ever damaged
+	DamageElapsed     time.Duration `json:"damageElapsed"`  // Time since the player was last damaged
+	DamageCooldown    time.Duration `json:"damageCooldown"` // How long to wait before taking damage again
+	LastDamageCellCol int           `json:"lastDamageCellCol"`
+	LastDamageCellRow int           `json:"lastDamageCellRow"`
+}
+
+// State captures the session as it is now
+func (g *GameSession) State() State {
+	now := g.Clock.Now()
+	state := State{
+		Time:              now,
+		Score:             g.Score,
+		CurrentLevel:      g.CurrentLevel,
+		MaxHearts:         g.MaxHearts,
+		CurrentHearts:     g.CurrentHearts,
+		Seed:              g.Seed,
+		Stats:             g.Stats,
+		Level:             g.Level,
+		TimerEnabled:      g.TimerEnabled,
+		TimerRemaining:    g.TimerRemaining,
+		TimerTotal:        g.TimerTotal,
+		RunTime:           g.RunTimer.Elapsed,
+		Splits:            slices.Clone(g.RunTimer.Splits),
+		IsFrozen:          g.IsFrozen,
+		FreezeDuration:    g.FreezeDuration,
+		LastFreezeCellCol: g.LastFreezeCellCol,
+		LastFreezeCellRow: g.LastFreezeCellRow,
+		CurrentCellCol:    g.CurrentCellCol,
+		CurrentCellRow:    g.CurrentCellRow,
+		DamageCooldown:    g.DamageCooldown,
+		LastDamageCellCol: g.LastDamageCellCol,
+		LastDamageCellRow: g.LastDamageCellRow,
+	}
+
+	if g.IsFrozen {
+		state.FreezeElapsed = now - g.FreezeStartTime
+	}
+	if g.HasTakenDamage {
+		state.Damaged = true
+		state.DamageElapsed = now - g.LastDamageTime
+	}
+
+	return state
+}
+
+// Restore brings the session back to a captured state. The configuration and the clock are kept as is.
+func (g *GameSession) Restore(state State) {
+	now := g.Clock.Now()
+
+	g.Score = state.Score
+	g.CurrentLevel = state.CurrentLevel
+	g.MaxHearts = state.MaxHearts
+	g.CurrentHearts = state.CurrentHearts
+	g.Seed = state.Seed
+	g.Stats = state.Stats
+	g.Level = state.Level
+
+	g.TimerEnabled = state.TimerEnabled
+	g.TimerRemaining = state.TimerRemaining
+	g.TimerTotal = state.TimerTotal
+	g.RunTimer.Elapsed = state.RunTime
+	g.RunTimer.Splits = slices.Clone(state.Splits)
+
+	g.IsFrozen = state.IsFrozen
+	g.FreezeStartTime = 0
+	if state.IsFrozen {
+		g.FreezeStartTime = now - state.FreezeElapsed
+	}
+	g.FreezeDuration = state.FreezeDuration
+	g.LastFreezeCellCol = state.LastFreezeCellCol
+	g.LastFreezeCellRow = state.LastFreezeCellRow
+	g.CurrentCellCol = state.CurrentCellCol
+	g.CurrentCellRow = state.CurrentCellRow
+
+	g.LastDamageTime = 0
+	g.HasTakenDamage = state.Damaged
+	if state.Damaged {
+		g.LastDamageTime = now - state.DamageElapsed
+	}
+	g.DamageCooldown = state.DamageCooldown
+	g.LastDamageCellCol = state.LastDamageCellCol
+	g.LastDamageCellRow = state.LastDamageCellRow
+}
@@ -0,0 +1,159 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/juanancid/maze-adventure/internal/core/components"
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
+	"github.com/juanancid/maze-adventure/internal/engine/utils"
+)
+
+// DefaultRegistry returns a registry with every component of the components package
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+
+	mustRegister(Register(r, "position", JSONCodec[components.Position]()))
+	mustRegister(Register(r, "size", JSONCodec[components.Size]()))
+	mustRegister(Register(r, "velocity", JSONCodec[components.Velocity]()))
+	mustRegister(Register(r, "input-controlled", JSONCodec[components.InputControlled]()))
+	mustRegister(Register(r, "exit", JSONCodec[components.Exit]()))
+	mustRegister(Register(r, "collectible", JSONCodec[components.Collectible]()))
+	mustRegister(Register(r, "patroller", JSONCodec[components.Patroller]()))
+	mustRegister(Register(r, "maze", mazeCodec()))
+	mustRegister(Register(r, "sprite", spriteCodec()))
+
+	return r
+}
+
+// mustRegister panics on registration errors, which are programming mistakes such as a duplicated name
+func mustRegister(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Cell types as saved in snapshots
+const (
+	cellRegular  = "regular"
+	cellDeadly   = "deadly"
+	cellFreezing = "freezing"
+)
+
+type savedCell struct {
+	Walls [4]bool `json:"walls"` // Top, right, bottom and left
+	Type  string  `json:"type"`
+}
+
+type savedMaze struct {
+	Cols       int           `json:"cols"`
+	Rows       int           `json:"rows"`
+	CellWidth  int           `json:"cellWidth"`
+	CellHeight int           `json:"cellHeight"`
+	Cells      [][]savedCell `json:"cells"` // Row by row
+}
+
+// mazeCodec saves every wall of every cell, the layout fields are not exported
+func mazeCodec() Codec[components.Maze] {
+	return Codec[components.Maze]{
+		Encode: func(maze *components.Maze) ([]byte, error) {
+			layout := maze.Layout
+			saved := savedMaze{
+				Cols:       layout.Cols(),
+				Rows:       layout.Rows(),
+				CellWidth:  maze.CellWidth,
+				CellHeight: maze.CellHeight,
+				Cells:      make([][]savedCell, layout.Rows()),
+			}
+
+			for y := range layout.Rows() {
+				saved.Cells[y] = make([]savedCell, layout.Cols())
+				for x := range layout.Cols() {
+					cell := layout.GetCell(x, y)
+					cellType := cellRegular
+					switch {
+					case cell.IsDeadly():
+						cellType = cellDeadly
+					case cell.IsFreezing():
+						cellType = cellFreezing
+					}
+					saved.Cells[y][x] = savedCell{Walls: cell.GetWalls(), Type: cellType}
+				}
+			}
+
+			return json.Marshal(saved)
+		},
+		Decode: func(data []byte) (*components.Maze, error) {
+			var saved savedMaze
+			if err := json.Unmarshal(data, &saved); err != nil {
+				return nil, err
+			}
+			if saved.Cols < 1 || saved.Rows < 1 || len(saved.Cells) != saved.Rows {
+				return nil, fmt.Errorf("maze of %dx%d cells has %d rows", saved.Cols, saved.Rows, len(saved.Cells))
+			}
+
+			grid := make([][]mazelayout.Cell, saved.Rows)
+			for y, row := range saved.Cells {
+				if len(row) != saved.Cols {
+					return nil, fmt.Errorf("maze row %d has %d cells, expected %d", y, len(row), saved.Cols)
+				}
+
+				grid[y] = make([]mazelayout.Cell, saved.Cols)
+				for x, cell := range row {
+					switch cell.Type {
+					case cellRegular:
+						grid[y][x] = mazelayout.NewRegularCell(cell.Walls)
+					case cellDeadly:
+						grid[y][x] = mazelayout.NewDeadlyCell(cell.Walls)
+					case cellFreezing:
+						grid[y][x] = mazelayout.NewFreezingCell(cell.Walls)
+					default:
+						return nil, fmt.Errorf("maze cell (%d, %d) has unknown type %q", x, y, cell.Type)
+					}
+				}
+			}
+
+			return &components.Maze{
+				Layout:     mazelayout.NewLayout(saved.Cols, saved.Rows, grid),
+				CellWidth:  saved.CellWidth,
+				CellHeight: saved.CellHeight,
+			}, nil
+		},
+	}
+}
+
+type savedSprite struct {
+	Image *utils.GameImage `json:"image"` // Nil if the sprite has no image
+}
+
+// spriteCodec saves which game image the sprite shows, the image itself comes from the assets
+func spriteCodec() Codec[components.Sprite] {
+	return Codec[components.Sprite]{
+		Encode: func(sprite *components.Sprite) ([]byte, error) {
+			var saved savedSprite
+			if sprite.Image != nil {
+				image, found := utils.LookupImage(sprite.Image)
+				if !found {
+					return nil, fmt.Errorf("sprite image is not a game image")
+				}
+				saved.Image = &image
+			}
+			return json.Marshal(saved)
+		},
+		Decode: func(data []byte) (*components.Sprite, error) {
+			var saved savedSprite
+			if err := json.Unmarshal(data, &saved); err != nil {
+				return nil, err
+			}
+
+			sprite := &components.Sprite{}
+			if saved.Image != nil {
+				sprite.Image = utils.GetImage(*saved.Image)
+				if sprite.Image == nil {
+					return nil, fmt.Errorf("sprite image %d cannot be loaded", *saved.Image)
+				}
+			}
+			return sprite, nil
+		},
+	}
+}
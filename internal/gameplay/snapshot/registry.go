@@ -0,0 +1,143 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/juanancid/maze-adventure/internal/core/entities"
+)
+
+// Codec converts a component of type T to bytes and back
+type Codec[T any] struct {
+	Encode func(component *T) ([]byte, error)
+	Decode func(data []byte) (*T, error)
+}
+
+// JSONCodec encodes the component with encoding/json. It fits components made only of exported fields.
+func JSONCodec[T any]() Codec[T] {
+	return Codec[T]{
+		Encode: func(component *T) ([]byte, error) {
+			return json.Marshal(component)
+		},
+		Decode: func(data []byte) (*T, error) {
+			component := new(T)
+			if err := json.Unmarshal(data, component); err != nil {
+				return nil, err
+			}
+			return component, nil
+		},
+	}
+}
+
+// Record is a saved component along with the entity it belongs to
+type Record struct {
+	Entity entities.Entity `json:"entity"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// registration is the type-erased view of a registered codec
+type registration struct {
+	name    string
+	capture func(world *entities.World) ([]Record, error)
+	restore func(world *entities.World, records []Record) error
+}
+
+// Registry knows how to save every component type that may be found in a world.
+// Component types are saved under a name, which must not change once snapshots are out there.
+type Registry struct {
+	registrations []registration
+	byName        map[string]registration
+	byType        map[reflect.Type]registration
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{
+		byName: make(map[string]registration),
+		byType: make(map[reflect.Type]registration),
+	}
+}
+
+// Register adds the codec of the T components under the given name
+func Register[T any](r *Registry, name string, codec Codec[T]) error {
+	componentType := reflect.TypeFor[*T]()
+	if _, exists := r.byName[name]; exists {
+		return fmt.Errorf("component name %q is already registered", name)
+	}
+	if _, exists := r.byType[componentType]; exists {
+		return fmt.Errorf("component type %v is already registered", componentType)
+	}
+
+	reg := registration{
+		name: name,
+		capture: func(world *entities.World) ([]Record, error) {
+			var records []Record
+			for entity, component := range entities.All[T](world) {
+				data, err := codec.Encode(component)
+				if err != nil {
+					return nil, fmt.Errorf("component %q of entity %d: %w", name, entity, err)
+				}
+				records = append(records, Record{Entity: entity, Data: data})
+			}
+			return records, nil
+		},
+		restore: func(world *entities.World, records []Record) error {
+			for _, record := range records {
+				if !world.IsAlive(record.Entity) {
+					return fmt.Errorf("component %q belongs to entity %d, which is not alive", name, record.Entity)
+				}
+				component, err := codec.Decode(record.Data)
+				if err != nil {
+					return fmt.Errorf("component %q of entity %d: %w", name, record.Entity, err)
+				}
+				entities.Add(world, record.Entity, component)
+			}
+			return nil
+		},
+	}
+
+	r.registrations = append(r.registrations, reg)
+	r.byName[name] = reg
+	r.byType[componentType] = reg
+	return nil
+}
+
+// capture saves every component of the world. It fails if the world holds components
+// of a type that is not registered, they would be silently lost.
+func (r *Registry) capture(world *entities.World) (map[string][]Record, error) {
+	for _, componentType := range world.ComponentTypes() {
+		if _, exists := r.byType[componentType]; !exists {
+			return nil, fmt.Errorf("component type %v is not registered", componentType)
+		}
+	}
+
+	components := make(map[string][]Record, len(r.registrations))
+	for _, reg := range r.registrations {
+		records, err := reg.capture(world)
+		if err != nil {
+			return nil, err
+		}
+		if len(records) > 0 {
+			components[reg.name] = records
+		}
+	}
+	return components, nil
+}
+
+// restore adds the saved components to a world whose entities are already allocated
+func (r *Registry) restore(world *entities.World, components map[string][]Record) error {
+	for name := range components {
+		if _, exists := r.byName[name]; !exists {
+			return fmt.Errorf("unknown component %q", name)
+		}
+	}
+
+	// Components are added in registration order so the result does not depend on map order
+	for _, reg := range r.registrations {
+		if err := reg.restore(world, components[reg.name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Package snapshot saves a running level, its world and the session playing it, and
+// restores it exactly: the same entities with the same handles and the same components.
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/juanancid/maze-adventure/internal/core/entities"
+	"github.com/juanancid/maze-adventure/internal/gameplay/session"
+)
+
+// Version is the format of the snapshots written by this build
+const Version = 2
+
+// ErrVersion is returned when a snapshot was written by an incompatible version of the game
+var ErrVersion = errors.New("snapshot version is not supported")
+
+// Level is a running level: the world being played and the session playing it
+type Level struct {
+	Pack    string // Name of the level pack the level belongs to
+	World   *entities.World
+	Session session.State
+
+	LevelStart session.State // Session when the level started, to restart it and score it
+	LevelTime  time.Duration // Time played in the level so far
+}
+
+// file is the layout of a snapshot on disk
+type file struct {
+	Version    int                 `json:"version"`
+	Pack       string              `json:"pack"`
+	Session    session.State       `json:"session"`
+	Entities   entities.Allocation `json:"entities"`
+	Components map[string][]Record `json:"components"`
+
+	LevelStart session.State `json:"levelStart"`
+	LevelTime  time.Duration `json:"levelTime"`
+}
+
+// Write saves the level to w. It must be called between frames, when no changes are
+// queued in the command buffer of the world.
+func (r *Registry) Write(w io.Writer, level Level) error {
+	if level.World.Commands().Len() > 0 {
+		return errors.New("cannot snapshot a world with queued commands")
+	}
+
+	components, err := r.capture(level.World)
+	if err != nil {
+		return fmt.Errorf("failed to snapshot the world: %w", err)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(file{
+		Version:    Version,
+		Pack:       level.Pack,
+		Session:    level.Session,
+		Entities:   level.World.Allocation(),
+		Components: components,
+		LevelStart: level.LevelStart,
+		LevelTime:  level.LevelTime,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// Read restores a level saved by Write
+func (r *Registry) Read(reader io.Reader) (Level, error) {
+	var saved file
+	if err := json.NewDecoder(reader).Decode(&saved); err != nil {
+		return Level{}, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	if saved.Version != Version {
+		return Level{}, fmt.Errorf("%w: version %d, expected %d", ErrVersion, saved.Version, Version)
+	}
+
+	world, err := entities.NewWorldFromAllocation(saved.Entities)
+	if err != nil {
+		return Level{}, fmt.Errorf("failed to restore entities: %w", err)
+	}
+	if err := r.restore(world, saved.Components); err != nil {
+		return Level{}, fmt.Errorf("failed to restore components: %w", err)
+	}
+
+	return Level{
+		Pack:       saved.Pack,
+		World:      world,
+		Session:    saved.Session,
+		LevelStart: saved.LevelStart,
+		LevelTime:  saved.LevelTime,
+	}, nil
+}
@@ -3,15 +3,18 @@ package states
 import (
 	"github.com/hajimehoshi/ebiten/v2"
 
+	"github.com/juanancid/maze-adventure/internal/engine/input"
 	"github.com/juanancid/maze-adventure/internal/engine/utils"
 	"github.com/juanancid/maze-adventure/internal/gameplay/config"
 	"github.com/juanancid/maze-adventure/internal/gameplay/levels"
+	"github.com/juanancid/maze-adventure/internal/gameplay/save"
 )
 
 type BootState struct {
 	stateManager *Manager
-	levelManager *levels.Manager
+	pack         *levels.Pack
 	config       config.GameConfig
+	saves        *save.Store
 
 	sprite *ebiten.Image
 
@@ -19,15 +22,16 @@ type BootState struct {
 	blinkOn    bool
 }
 
-func NewBootState(stateManager *Manager, levelManager *levels.Manager, config config.GameConfig) *BootState {
+func NewBootState(stateManager *Manager, pack *levels.Pack, config config.GameConfig, saves *save.Store) *BootState {
 	// Preload all game assets
 	utils.PreloadImages()
 	utils.PreloadSounds()
 
 	return &BootState{
 		stateManager: stateManager,
-		levelManager: levelManager,
+		pack:         pack,
 		config:       config,
+		saves:        saves,
 		sprite:       utils.GetImage(utils.ImageIntroIllustration),
 	}
 }
@@ -39,6 +43,10 @@ func (s *BootState) OnEnter() {
 
 func (s *BootState) OnExit() {}
 
+func (s *BootState) OnPause() {}
+
+func (s *BootState) OnResume() {}
+
 func (s *BootState) Update() error {
 	s.blinkTimer++
 	if s.blinkTimer >= 60 {
@@ -46,9 +54,9 @@ func (s *BootState) Update() error {
 		s.blinkOn = !s.blinkOn
 	}
 
-	if ebiten.IsKeyPressed(ebiten.KeySpace) {
-		playingState := NewPlayingState(s.stateManager, s.levelManager, s.config)
-		s.stateManager.ChangeState(playingState)
+	if ebiten.IsKeyPressed(ebiten.KeySpace) || input.IsGamepadButtonPressed(ebiten.StandardGamepadButtonRightBottom) {
+		titleState := NewTitleState(s.stateManager, s.pack, s.config, s.saves)
+		s.stateManager.FadeTo(titleState)
 	}
 	return nil
 }
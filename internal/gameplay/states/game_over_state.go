@@ -1,43 +1,77 @@
 package states
 
 import (
-	"os"
+	"fmt"
+	"log"
 
 	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/juanancid/maze-adventure/internal/gameplay/config"
+	"github.com/juanancid/maze-adventure/internal/gameplay/levels"
+	"github.com/juanancid/maze-adventure/internal/gameplay/save"
 )
 
 type GameOverState struct {
 	manager *Manager
+	pack    *levels.Pack
+	config  config.GameConfig
+	saves   *save.Store
+	retry   save.Data      // The run at the start of the level that was lost, with every heart back
+	run     save.RunRecord // The run that was lost, for the leaderboard
 
-	blinkTimer int
-	blinkOn    bool
+	menu     *menu
+	initials *initialsEntry // Asks for initials before the menu if the run makes it to the leaderboard
 }
 
-func NewGameOverState(manager *Manager) *GameOverState {
-	return &GameOverState{
+func NewGameOverState(manager *Manager, pack *levels.Pack, gameConfig config.GameConfig, saves *save.Store, retry save.Data, run save.RunRecord) *GameOverState {
+	s := &GameOverState{
 		manager: manager,
+		pack:    pack,
+		config:  gameConfig,
+		saves:   saves,
+		retry:   retry,
+		run:     run,
+	}
+
+	// A daily challenge is a single attempt
+	var items []menuItem
+	if s.config.Mode != config.ModeDaily {
+		items = append(items,
+			menuItem{label: "RETRY LEVEL", action: s.retryLevel},
+			menuItem{label: "RESTART RUN", action: s.restartRun},
+		)
 	}
+	items = append(items, menuItem{label: "TITLE", action: s.title})
+
+	s.menu = newMenu(items...)
+	return s
 }
 
 func (s *GameOverState) OnEnter() {
-	s.blinkTimer = 0
-	s.blinkOn = false
+	s.initials = offerLeaderboard(s.saves, leaderboardName(s.pack, s.config), s.run)
+	s.menu.reset()
 }
 
 func (s *GameOverState) OnExit() {
 	// Cleanup explicitly, if needed
 }
 
+func (s *GameOverState) OnPause() {}
+
+func (s *GameOverState) OnResume() {}
+
 func (s *GameOverState) Update() error {
-	s.blinkTimer++
-	if s.blinkTimer >= 60 {
-		s.blinkTimer = 0
-		s.blinkOn = !s.blinkOn
+	if s.initials != nil {
+		s.initials.Update()
+		if s.initials.done {
+			recordRun(s.saves, leaderboardName(s.pack, s.config), s.run, s.initials.initials())
+			s.initials = nil
+			s.menu.reset()
+		}
+		return nil
 	}
 
-	if ebiten.IsKeyPressed(ebiten.KeyEscape) {
-		os.Exit(0)
-	}
+	s.menu.Update()
 	return nil
 }
 
@@ -52,10 +86,28 @@ func (s *GameOverState) Draw(screen *ebiten.Image) {
 	drawCenteredText(screen, "Life support systems offline.", 135, regularFontSize)
 	drawCenteredText(screen, "Emergency shutdown initiated.", 150, regularFontSize)
 
-	drawCenteredText(screen, "SYSTEM SHUTDOWN", 200, regularFontSize)
-	drawCenteredText(screen, "Thank you for playing.", 215, regularFontSize)
+	if s.initials != nil {
+		drawCenteredText(screen, fmt.Sprintf("HIGH SCORE %d! ENTER YOUR INITIALS", s.run.Score), 180, regularFontSize)
+		s.initials.Draw(screen, 205)
+		return
+	}
+	s.menu.Draw(screen, 190)
+}
 
-	if s.blinkOn {
-		drawCenteredText(screen, "Press ESC to disconnect…", 250, regularFontSize)
+func (s *GameOverState) retryLevel() {
+	playingState, err := NewResumedPlayingState(s.manager, s.pack, s.config, s.saves, s.retry)
+	if err != nil {
+		log.Printf("Cannot retry level %d: %v", s.retry.Level, err)
+		s.restartRun()
+		return
 	}
+	s.manager.FadeTo(playingState)
+}
+
+func (s *GameOverState) restartRun() {
+	s.manager.FadeTo(NewPlayingState(s.manager, newLevelManager(s.pack, s.config), s.config, s.saves))
+}
+
+func (s *GameOverState) title() {
+	s.manager.FadeTo(NewTitleState(s.manager, s.pack, s.config, s.saves))
 }
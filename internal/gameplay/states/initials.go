@@ -0,0 +1,130 @@
+package states
+
+import (
+	"log"
+	"strings"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/juanancid/maze-adventure/internal/engine/input"
+	"github.com/juanancid/maze-adventure/internal/gameplay/config"
+	"github.com/juanancid/maze-adventure/internal/gameplay/levels"
+	"github.com/juanancid/maze-adventure/internal/gameplay/save"
+)
+
+const initialsAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// initialsEntry lets the player write their initials one letter at a time: up and down
+// change the letter, left and right move between letters, and pick goes to the next
+// letter or confirms the last one
+type initialsEntry struct {
+	letters [save.InitialsLength]int // Index of every letter in initialsAlphabet
+	cursor  int
+	done    bool
+	input   *input.Handler
+}
+
+func newInitialsEntry() *initialsEntry {
+	e := &initialsEntry{input: input.NewHandler()}
+	e.ignoreHeldKeys()
+	return e
+}
+
+func (e *initialsEntry) ignoreHeldKeys() {
+	for _, b := range []binding{bindUp, bindDown, bindLeft, bindRight, bindPick} {
+		b.ignoreHeld(e.input)
+	}
+}
+
+func (e *initialsEntry) Update() {
+	up := bindUp.justPressed(e.input)
+	down := bindDown.justPressed(e.input)
+	left := bindLeft.justPressed(e.input)
+	right := bindRight.justPressed(e.input)
+	pick := bindPick.justPressed(e.input)
+
+	letters := len(initialsAlphabet)
+	switch {
+	case up:
+		e.letters[e.cursor] = (e.letters[e.cursor] + 1) % letters
+	case down:
+		e.letters[e.cursor] = (e.letters[e.cursor] + letters - 1) % letters
+	case left:
+		e.cursor = max(e.cursor-1, 0)
+	case right:
+		e.cursor = min(e.cursor+1, len(e.letters)-1)
+	case pick:
+		if e.cursor == len(e.letters)-1 {
+			e.done = true
+		} else {
+			e.cursor++
+		}
+	}
+}
+
+// Draw writes the initials centered at the given height, with a mark under the letter being changed
+func (e *initialsEntry) Draw(screen *ebiten.Image, y float64) {
+	letters := make([]string, len(e.letters))
+	marks := make([]string, len(e.letters))
+	for i, letter := range e.letters {
+		letters[i] = string(initialsAlphabet[letter])
+		marks[i] = " "
+	}
+	marks[e.cursor] = "^"
+
+	drawCenteredText(screen, strings.Join(letters, " "), y, titleFontSize)
+	drawCenteredText(screen, strings.Join(marks, " "), y+18, titleFontSize)
+}
+
+// initials returns the letters written
+func (e *initialsEntry) initials() string {
+	var initials strings.Builder
+	for _, letter := range e.letters {
+		initials.WriteByte(initialsAlphabet[letter])
+	}
+	return initials.String()
+}
+
+// leaderboardName returns the name of the leaderboard the runs of the configured mode are ranked on
+func leaderboardName(pack *levels.Pack, gameConfig config.GameConfig) string {
+	switch gameConfig.Mode {
+	case config.ModeEndless:
+		return levels.EndlessName
+	case config.ModeDaily:
+		return levels.DailyName
+	}
+	return pack.Name
+}
+
+// offerLeaderboard returns an entry for the initials of the run if it makes it to the
+// leaderboard of the pack, and nil otherwise
+func offerLeaderboard(saves *save.Store, pack string, run save.RunRecord) *initialsEntry {
+	if saves == nil {
+		return nil
+	}
+
+	records, err := saves.LoadRecords()
+	if err != nil {
+		log.Printf("Replacing unreadable records: %v", err)
+	}
+	if !records.Qualifies(pack, run.Score) {
+		return nil
+	}
+	return newInitialsEntry()
+}
+
+// recordRun puts the run on the leaderboard of the pack under the given initials
+func recordRun(saves *save.Store, pack string, run save.RunRecord, initials string) {
+	records, err := saves.LoadRecords()
+	if err != nil {
+		log.Printf("Replacing unreadable records: %v", err)
+	}
+
+	run.Initials = initials
+	if records.AddRun(pack, run) < 0 {
+		return
+	}
+	if err := saves.SaveRecords(records); err != nil {
+		log.Printf("Failed to save records: %v", err)
+	}
+}
@@ -0,0 +1,115 @@
+package states
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/juanancid/maze-adventure/internal/engine/input"
+	"github.com/juanancid/maze-adventure/internal/gameplay/config"
+	"github.com/juanancid/maze-adventure/internal/gameplay/levels"
+	"github.com/juanancid/maze-adventure/internal/gameplay/save"
+)
+
+const levelSelectVisibleLevels = 8
+
+// LevelSelectState lists the levels of the pack with their records, and starts a run
+// at any level unlocked by completing the one before it
+type LevelSelectState struct {
+	manager *Manager
+	pack    *levels.Pack
+	config  config.GameConfig
+	saves   *save.Store
+
+	menu  *menu
+	input *input.Handler
+}
+
+func NewLevelSelectState(manager *Manager, pack *levels.Pack, config config.GameConfig, saves *save.Store) *LevelSelectState {
+	return &LevelSelectState{
+		manager: manager,
+		pack:    pack,
+		config:  config,
+		saves:   saves,
+		input:   input.NewHandler(),
+	}
+}
+
+func (s *LevelSelectState) OnEnter() {
+	records := save.NewRecords()
+	if s.saves != nil {
+		var err error
+		if records, err = s.saves.LoadRecords(); err != nil {
+			log.Printf("Cannot show level records: %v", err)
+		}
+	}
+
+	items := make([]menuItem, 0, len(s.pack.Levels))
+	for i := range s.pack.Levels {
+		levelNumber := i + 1
+		items = append(items, menuItem{
+			label:  levelLabel(records, s.pack.Name, levelNumber),
+			action: func() { s.startAt(records, levelNumber) },
+		})
+	}
+
+	s.menu = newMenu(items...)
+	s.menu.maxVisible = levelSelectVisibleLevels
+	s.menu.reset()
+	bindBack.ignoreHeld(s.input)
+}
+
+func (s *LevelSelectState) OnExit() {}
+
+func (s *LevelSelectState) OnPause() {}
+
+func (s *LevelSelectState) OnResume() {}
+
+func (s *LevelSelectState) Update() error {
+	if bindBack.justPressed(s.input) {
+		s.manager.PopState()
+		return nil
+	}
+
+	s.menu.Update()
+	return nil
+}
+
+func (s *LevelSelectState) Draw(screen *ebiten.Image) {
+	screen.Fill(bgColor)
+
+	drawCenteredText(screen, "SECTOR MAP", 20, titleFontSize)
+	drawCenteredText(screen, "SECTOR   BEST SCORE   BEST TIME", 55, regularFontSize)
+	s.menu.Draw(screen, 80)
+	drawCenteredText(screen, "ESC to go back", 250, regularFontSize)
+}
+
+// levelLabel writes the row of a level, with its records or its locked status
+func levelLabel(records *save.Records, pack string, levelNumber int) string {
+	if !records.IsUnlocked(pack, levelNumber) {
+		return fmt.Sprintf("%6d   %-22s", levelNumber, "LOCKED")
+	}
+
+	record, completed := records.Level(pack, levelNumber)
+	if !completed {
+		return fmt.Sprintf("%6d   %-22s", levelNumber, "NOT CLEARED")
+	}
+	return fmt.Sprintf("%6d   %10d   %9s", levelNumber, record.BestScore, formatDuration(record.BestTime))
+}
+
+// startAt starts a new run at the level, if it is unlocked
+func (s *LevelSelectState) startAt(records *save.Records, levelNumber int) {
+	if !records.IsUnlocked(s.pack.Name, levelNumber) {
+		return
+	}
+
+	levelManager, err := levels.NewManagerForPackWithStartingLevel(s.pack, levelNumber)
+	if err != nil {
+		log.Printf("Cannot start at level %d: %v", levelNumber, err)
+		return
+	}
+	runConfig := s.config
+	runConfig.Mode = config.ModeCampaign
+	s.manager.FadeTo(NewPlayingState(s.manager, levelManager, runConfig, s.saves))
+}
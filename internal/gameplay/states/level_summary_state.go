@@ -0,0 +1,103 @@
+package states
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/juanancid/maze-adventure/internal/engine/config"
+	"github.com/juanancid/maze-adventure/internal/gameplay/session"
+)
+
+// LevelSummaryState is drawn over a completed level until the player moves on to the next one
+type LevelSummaryState struct {
+	manager *Manager
+	playing *PlayingState
+
+	summary session.LevelSummary
+	score   int // Score with the bonuses of the level
+
+	menu *menu
+}
+
+func NewLevelSummaryState(manager *Manager, playing *PlayingState, summary session.LevelSummary, score int) *LevelSummaryState {
+	s := &LevelSummaryState{
+		manager: manager,
+		playing: playing,
+		summary: summary,
+		score:   score,
+	}
+
+	s.menu = newMenu(menuItem{label: "CONTINUE", action: s.next})
+	return s
+}
+
+func (s *LevelSummaryState) OnEnter() {
+	s.menu.reset()
+}
+
+func (s *LevelSummaryState) OnExit() {}
+
+func (s *LevelSummaryState) OnPause() {}
+
+func (s *LevelSummaryState) OnResume() {}
+
+// DrawBelow keeps the completed level visible under the summary
+func (s *LevelSummaryState) DrawBelow() bool {
+	return true
+}
+
+func (s *LevelSummaryState) UpdateBelow() bool {
+	return false
+}
+
+func (s *LevelSummaryState) Update() error {
+	s.menu.Update()
+	return nil
+}
+
+func (s *LevelSummaryState) Draw(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, 0, 0, config.ScreenWidth, config.ScreenHeight, dimColor, false)
+
+	stats := s.summary.Stats
+	drawCenteredText(screen, fmt.Sprintf("SECTOR %d CLEARED", s.summary.Level), 30, titleFontSize)
+
+	timeLeft := "--:--"
+	if s.summary.TimerEnabled {
+		timeLeft = formatDuration(time.Duration(s.summary.TimerRemaining * float64(time.Second)))
+	}
+	drawCenteredText(screen, fmt.Sprintf("TIME: %s   TIME LEFT: %s", formatDuration(s.summary.PlayTime), timeLeft), 65, regularFontSize)
+	drawCenteredText(screen, fmt.Sprintf("DATA FRAGMENTS: %d/%d", stats.CollectiblesPicked, stats.CollectiblesTotal), 80, regularFontSize)
+	if s.summary.Split != nil {
+		drawCenteredText(screen, fmt.Sprintf("HITS: %d   FREEZES: %d", stats.Hits, stats.Freezes), 95, regularFontSize)
+	} else {
+		drawCenteredText(screen, fmt.Sprintf("HEARTS LOST: %d   FREEZES: %d", stats.HeartsLost, stats.Freezes), 95, regularFontSize)
+	}
+
+	y := 120.0
+	for _, bonus := range s.summary.Bonuses {
+		drawCenteredText(screen, fmt.Sprintf("%-12s %+6d", bonus.Label, bonus.Points), y, regularFontSize)
+		y += 15
+	}
+	drawCenteredText(screen, fmt.Sprintf("%-12s %+6d", "TOTAL BONUS", s.summary.BonusPoints()), y, regularFontSize)
+
+	drawCenteredText(screen, fmt.Sprintf("SCORE: %d", s.score), y+25, regularFontSize)
+	if split := s.summary.Split; split != nil {
+		splitText := "SPLIT: " + session.FormatRunTime(split.Time)
+		if split.HasBest {
+			splitText += " (" + session.FormatSplitDelta(split.Delta) + ")"
+		}
+		drawCenteredText(screen, splitText, y+40, regularFontSize)
+	}
+	s.menu.Draw(screen, 230)
+}
+
+// next starts the next level, or ends the run if this was the last one
+func (s *LevelSummaryState) next() {
+	s.manager.Transition(NewWipe(defaultTransitionTicks), func() {
+		s.manager.PopState()
+		s.playing.loadNextLevel()
+	})
+}
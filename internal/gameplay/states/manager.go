@@ -2,43 +2,187 @@ package states
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/hajimehoshi/ebiten/v2"
 )
 
+// Manager keeps a stack of states. The top one is the current state, and the states
+// below it are drawn and updated only as far as the Layered states above them let through.
 type Manager struct {
-	current State
+	stack   []State
+	changes int // Counts the changes to the stack, to notice them while updating
+
+	transition *runningTransition
+	toasts     []toast // Announcements waiting to be shown, the one on screen first
 }
 
 func NewManager(initial State) *Manager {
+	m := &Manager{}
 	if initial != nil {
-		initial.OnEnter()
+		m.PushState(initial)
 	}
-
-	return &Manager{current: initial}
+	return m
 }
 
+// ChangeState replaces every state of the stack with the given one
 func (m *Manager) ChangeState(next State) {
-	if m.current != nil {
-		m.current.OnExit()
+	for len(m.stack) > 0 {
+		m.pop()
+	}
+	m.changes++
+
+	if next != nil {
+		m.push(next)
+	}
+}
+
+// PushState puts a state on top of the current one, which is paused until the new one is popped
+func (m *Manager) PushState(next State) {
+	if current := m.Current(); current != nil {
+		current.OnPause()
+	}
+	m.push(next)
+}
+
+// PopState removes the current state and resumes the one below it
+func (m *Manager) PopState() {
+	if len(m.stack) == 0 {
+		return
 	}
 
-	m.current = next
+	m.pop()
+	if current := m.Current(); current != nil {
+		current.OnResume()
+	}
+}
 
-	if m.current != nil {
-		m.current.OnEnter()
+// ReplaceState swaps the current state for another one. The state below is neither resumed nor paused.
+func (m *Manager) ReplaceState(next State) {
+	if len(m.stack) > 0 {
+		m.pop()
 	}
+	m.push(next)
+}
+
+// Transition plays the effect and makes the change half way through it, when the screen is fully covered.
+// States are not updated while the effect plays. Starting a transition finishes the running one right away.
+func (m *Manager) Transition(effect TransitionEffect, change func()) {
+	m.finishTransition()
+	m.transition = &runningTransition{effect: effect, change: change}
+}
+
+// FadeTo changes to the given state behind a fade
+func (m *Manager) FadeTo(next State) {
+	m.Transition(NewFade(defaultTransitionTicks), func() {
+		m.ChangeState(next)
+	})
+}
+
+// Current returns the state on top of the stack, or nil
+func (m *Manager) Current() State {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	return m.stack[len(m.stack)-1]
 }
 
 func (m *Manager) Update() error {
-	if m.current == nil {
+	m.updateToasts()
+
+	if m.transition != nil {
+		m.updateTransition()
+		return nil
+	}
+
+	if len(m.stack) == 0 {
 		return fmt.Errorf("no current state to update")
 	}
-	return m.current.Update()
+
+	// States are updated from the bottom up. Once the stack changes the remaining states
+	// are left for the next frame, they may no longer be in the stack.
+	changes := m.changes
+	for _, state := range slices.Clone(m.stack[m.lowestUpdated():]) {
+		if err := state.Update(); err != nil {
+			return err
+		}
+		if m.changes != changes {
+			break
+		}
+	}
+	return nil
 }
 
 func (m *Manager) Draw(screen *ebiten.Image) {
-	if m.current != nil {
-		m.current.Draw(screen)
+	for _, state := range m.stack[m.lowestDrawn():] {
+		state.Draw(screen)
+	}
+
+	if m.transition != nil {
+		m.transition.draw(screen)
+	}
+	m.drawToast(screen)
+}
+
+// lowestDrawn returns the position of the lowest state to draw, the states above it all draw below them
+func (m *Manager) lowestDrawn() int {
+	i := len(m.stack) - 1
+	for ; i > 0; i-- {
+		if drawBelow, _ := layers(m.stack[i]); !drawBelow {
+			break
+		}
+	}
+	return max(i, 0)
+}
+
+// lowestUpdated returns the position of the lowest state to update, the states above it all update below them
+func (m *Manager) lowestUpdated() int {
+	i := len(m.stack) - 1
+	for ; i > 0; i-- {
+		if _, updateBelow := layers(m.stack[i]); !updateBelow {
+			break
+		}
+	}
+	return max(i, 0)
+}
+
+func (m *Manager) push(next State) {
+	m.stack = append(m.stack, next)
+	m.changes++
+	next.OnEnter()
+}
+
+func (m *Manager) pop() {
+	last := len(m.stack) - 1
+	top := m.stack[last]
+	m.stack[last] = nil
+	m.stack = m.stack[:last]
+	m.changes++
+	top.OnExit()
+}
+
+func (m *Manager) updateTransition() {
+	t := m.transition
+	t.ticks++
+	if !t.changed && t.ticks >= t.effect.Ticks()/2 {
+		t.changed = true
+		t.change()
+	}
+	if t.ticks >= t.effect.Ticks() && m.transition == t {
+		m.transition = nil
+	}
+}
+
+// finishTransition makes the change of the running transition, if it was not made yet, and stops it
+func (m *Manager) finishTransition() {
+	t := m.transition
+	if t == nil {
+		return
+	}
+
+	m.transition = nil
+	if !t.changed {
+		t.changed = true
+		t.change()
 	}
 }
@@ -0,0 +1,122 @@
+package states
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/juanancid/maze-adventure/internal/engine/input"
+)
+
+const menuLineHeight = 16
+
+// binding is a set of keys and gamepad buttons doing the same thing in menus
+type binding struct {
+	keys    []ebiten.Key
+	buttons []ebiten.StandardGamepadButton
+}
+
+var (
+	bindUp    = binding{keys: []ebiten.Key{ebiten.KeyArrowUp, ebiten.KeyW}, buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftTop}}
+	bindDown  = binding{keys: []ebiten.Key{ebiten.KeyArrowDown, ebiten.KeyS}, buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftBottom}}
+	bindLeft  = binding{keys: []ebiten.Key{ebiten.KeyArrowLeft, ebiten.KeyA}, buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftLeft}}
+	bindRight = binding{keys: []ebiten.Key{ebiten.KeyArrowRight, ebiten.KeyD}, buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonLeftRight}}
+	bindPick  = binding{keys: []ebiten.Key{ebiten.KeyEnter, ebiten.KeySpace}, buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightBottom}}
+	bindBack  = binding{keys: []ebiten.Key{ebiten.KeyEscape}, buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonRightRight}}
+
+	menuBindings = []binding{bindUp, bindDown, bindPick}
+)
+
+// justPressed returns true if any key or button of the binding was just pressed. Every one
+// of them is checked so the handler keeps track of all of them.
+func (b binding) justPressed(handler *input.Handler) bool {
+	pressed := false
+	for _, key := range b.keys {
+		if handler.IsKeyJustPressed(key) {
+			pressed = true
+		}
+	}
+	for _, button := range b.buttons {
+		if handler.IsGamepadButtonJustPressed(button) {
+			pressed = true
+		}
+	}
+	return pressed
+}
+
+// ignoreHeld keeps the keys and buttons held down right now from counting until they are pressed again
+func (b binding) ignoreHeld(handler *input.Handler) {
+	handler.Prime(b.keys...)
+	handler.PrimeButtons(b.buttons...)
+}
+
+type menuItem struct {
+	label  string
+	action func()
+}
+
+// menu is a vertical list of options picked with the arrow keys (or W/S, or the d-pad)
+// and Enter (or Space, or the bottom face button)
+type menu struct {
+	items      []menuItem
+	selected   int
+	maxVisible int // Number of options shown at once, 0 shows them all
+	input      *input.Handler
+}
+
+func newMenu(items ...menuItem) *menu {
+	return &menu{
+		items: items,
+		input: input.NewHandler(),
+	}
+}
+
+// reset selects the first option and ignores the keys held while the menu opens
+func (m *menu) reset() {
+	m.selected = 0
+	m.ignoreHeldKeys()
+}
+
+// ignoreHeldKeys keeps the keys held down right now from picking an option until they are pressed again
+func (m *menu) ignoreHeldKeys() {
+	for _, b := range menuBindings {
+		b.ignoreHeld(m.input)
+	}
+}
+
+// setLabel changes the text of an option, for options showing a value
+func (m *menu) setLabel(index int, label string) {
+	m.items[index].label = label
+}
+
+// Update moves the selection and runs the action of the selected option when it is picked
+func (m *menu) Update() {
+	up := bindUp.justPressed(m.input)
+	down := bindDown.justPressed(m.input)
+	pick := bindPick.justPressed(m.input)
+
+	switch {
+	case up:
+		m.selected = (m.selected + len(m.items) - 1) % len(m.items)
+	case down:
+		m.selected = (m.selected + 1) % len(m.items)
+	case pick:
+		m.items[m.selected].action()
+	}
+}
+
+// Draw writes the options centered from the given height down, the selected one between arrows.
+// Long menus scroll to keep the selected option in view.
+func (m *menu) Draw(screen *ebiten.Image, y float64) {
+	first, last := 0, len(m.items)
+	if m.maxVisible > 0 && len(m.items) > m.maxVisible {
+		first = min(max(m.selected-m.maxVisible/2, 0), len(m.items)-m.maxVisible)
+		last = first + m.maxVisible
+	}
+
+	for i := first; i < last; i++ {
+		label := m.items[i].label
+		if i == m.selected {
+			label = "> " + label + " <"
+		}
+		drawCenteredText(screen, label, y+float64((i-first)*menuLineHeight), regularFontSize)
+	}
+}
@@ -0,0 +1,147 @@
+package states
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/juanancid/maze-adventure/internal/engine/input"
+	"github.com/juanancid/maze-adventure/internal/gameplay/config"
+	"github.com/juanancid/maze-adventure/internal/gameplay/levels"
+	"github.com/juanancid/maze-adventure/internal/gameplay/save"
+)
+
+// ModeSelectState picks the kind of run a new game starts
+type ModeSelectState struct {
+	manager *Manager
+	pack    *levels.Pack
+	config  config.GameConfig
+	saves   *save.Store
+
+	menu  *menu
+	input *input.Handler
+
+	dailyAttempt *save.DailyAttempt // Today's attempt at the daily challenge, nil if it was not played
+}
+
+func NewModeSelectState(manager *Manager, pack *levels.Pack, gameConfig config.GameConfig, saves *save.Store) *ModeSelectState {
+	s := &ModeSelectState{
+		manager: manager,
+		pack:    pack,
+		config:  gameConfig,
+		saves:   saves,
+		input:   input.NewHandler(),
+	}
+
+	s.menu = newMenu(
+		menuItem{label: "CAMPAIGN", action: func() { s.start(config.ModeCampaign) }},
+		menuItem{label: "ENDLESS", action: func() { s.start(config.ModeEndless) }},
+		menuItem{label: "DAILY CHALLENGE", action: s.startDaily},
+		menuItem{label: "TIME ATTACK", action: func() { s.start(config.ModeTimeAttack) }},
+		menuItem{label: "BACK", action: s.back},
+	)
+	return s
+}
+
+func (s *ModeSelectState) OnEnter() {
+	s.menu.reset()
+	bindBack.ignoreHeld(s.input)
+	s.loadDailyAttempt()
+}
+
+func (s *ModeSelectState) OnExit() {}
+
+func (s *ModeSelectState) OnPause() {}
+
+func (s *ModeSelectState) OnResume() {}
+
+func (s *ModeSelectState) Update() error {
+	if bindBack.justPressed(s.input) {
+		s.back()
+		return nil
+	}
+
+	s.menu.Update()
+	return nil
+}
+
+func (s *ModeSelectState) Draw(screen *ebiten.Image) {
+	screen.Fill(bgColor)
+
+	drawCenteredText(screen, "NEW GAME", 80, titleFontSize)
+	s.menu.Draw(screen, 120)
+
+	switch s.menu.selected {
+	case 0:
+		drawCenteredText(screen, "Explore every sector of the map.", 200, regularFontSize)
+	case 1:
+		drawCenteredText(screen, "Go deeper and deeper until your hearts run out.", 200, regularFontSize)
+	case 2:
+		if s.dailyAttempt != nil {
+			drawCenteredText(screen, fmt.Sprintf("Played today: %d points at sector %d. Back tomorrow!", s.dailyAttempt.Score, s.dailyAttempt.Level), 200, regularFontSize)
+		} else {
+			drawCenteredText(screen, "Today's five sectors, the same for everyone. One attempt.", 200, regularFontSize)
+		}
+	case 3:
+		drawCenteredText(screen, "Every sector against the clock. Hits cost time, not hearts.", 200, regularFontSize)
+	}
+}
+
+// start begins a new run in the given mode
+func (s *ModeSelectState) start(mode config.Mode) {
+	runConfig := s.config
+	runConfig.Mode = mode
+	s.manager.FadeTo(NewPlayingState(s.manager, newLevelManager(s.pack, runConfig), runConfig, s.saves))
+}
+
+// loadDailyAttempt reads whether today's daily challenge was already attempted
+func (s *ModeSelectState) loadDailyAttempt() {
+	s.dailyAttempt = nil
+	if s.saves == nil {
+		return
+	}
+
+	records, err := s.saves.LoadRecords()
+	if err != nil {
+		log.Printf("Failed to load records: %v", err)
+		return
+	}
+	if attempt, attempted := records.DailyAttempt(levels.DailyDate(time.Now())); attempted {
+		s.dailyAttempt = &attempt
+	}
+}
+
+// startDaily uses up today's attempt at the daily challenge and starts it
+func (s *ModeSelectState) startDaily() {
+	if s.dailyAttempt != nil {
+		return
+	}
+
+	now := time.Now()
+	if s.saves != nil {
+		records, err := s.saves.LoadRecords()
+		if err != nil {
+			log.Printf("Replacing unreadable records: %v", err)
+		}
+		if !records.StartDaily(levels.DailyDate(now), levels.DailySeed(now)) {
+			s.loadDailyAttempt()
+			return
+		}
+		if err := s.saves.SaveRecords(records); err != nil {
+			log.Printf("Failed to save records: %v", err)
+		}
+	}
+
+	playingState, err := NewDailyPlayingState(s.manager, s.pack, s.config, s.saves, now)
+	if err != nil {
+		log.Printf("Failed to start the daily challenge: %v", err)
+		return
+	}
+	s.manager.FadeTo(playingState)
+}
+
+func (s *ModeSelectState) back() {
+	s.manager.PopState()
+}
@@ -0,0 +1,108 @@
+package states
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/juanancid/maze-adventure/internal/engine/config"
+	"github.com/juanancid/maze-adventure/internal/engine/input"
+)
+
+var dimColor = color.RGBA{R: 0x00, G: 0x13, B: 0x1F, A: 0xC0}
+
+// bindPause opens and closes the pause menu
+var bindPause = binding{
+	keys:    []ebiten.Key{ebiten.KeyEscape, ebiten.KeyP},
+	buttons: []ebiten.StandardGamepadButton{ebiten.StandardGamepadButtonCenterRight, ebiten.StandardGamepadButtonRightRight},
+}
+
+// PauseState is drawn over the playing state, which is paused by the manager until this state is popped
+type PauseState struct {
+	manager *Manager
+	playing *PlayingState
+
+	menu  *menu
+	input *input.Handler
+}
+
+func NewPauseState(manager *Manager, playing *PlayingState) *PauseState {
+	s := &PauseState{
+		manager: manager,
+		playing: playing,
+		input:   input.NewHandler(),
+	}
+
+	items := []menuItem{{label: "RESUME", action: s.resume}}
+	if playing.allowsRetries() {
+		items = append(items, menuItem{label: "RESTART LEVEL", action: s.restartLevel})
+	}
+	items = append(items,
+		menuItem{label: "SETTINGS", action: s.openSettings},
+		menuItem{label: "QUIT TO TITLE", action: s.quitToTitle},
+	)
+
+	s.menu = newMenu(items...)
+	return s
+}
+
+func (s *PauseState) OnEnter() {
+	s.menu.reset()
+	bindPause.ignoreHeld(s.input)
+}
+
+func (s *PauseState) OnExit() {}
+
+func (s *PauseState) OnPause() {}
+
+// OnResume ignores the keys still held when coming back from the settings
+func (s *PauseState) OnResume() {
+	s.menu.ignoreHeldKeys()
+	bindPause.ignoreHeld(s.input)
+}
+
+// DrawBelow keeps the frozen level visible under the menu
+func (s *PauseState) DrawBelow() bool {
+	return true
+}
+
+func (s *PauseState) UpdateBelow() bool {
+	return false
+}
+
+func (s *PauseState) Update() error {
+	if bindPause.justPressed(s.input) {
+		s.resume()
+		return nil
+	}
+
+	s.menu.Update()
+	return nil
+}
+
+func (s *PauseState) Draw(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, 0, 0, config.ScreenWidth, config.ScreenHeight, dimColor, false)
+
+	drawCenteredText(screen, "PAUSED", 80, titleFontSize)
+	s.menu.Draw(screen, 120)
+}
+
+func (s *PauseState) resume() {
+	s.manager.PopState()
+}
+
+func (s *PauseState) restartLevel() {
+	s.manager.Transition(NewWipe(defaultTransitionTicks), func() {
+		s.playing.restartLevel()
+		s.manager.PopState()
+	})
+}
+
+func (s *PauseState) openSettings() {
+	s.manager.PushState(NewSettingsState(s.manager))
+}
+
+func (s *PauseState) quitToTitle() {
+	s.playing.quitToTitle()
+}
@@ -1,6 +1,9 @@
 package states
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"log"
 	"reflect"
 	"time"
@@ -8,12 +11,20 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 
 	"github.com/juanancid/maze-adventure/internal/core/entities"
+	"github.com/juanancid/maze-adventure/internal/core/queries"
+	"github.com/juanancid/maze-adventure/internal/engine/clock"
+	engineconfig "github.com/juanancid/maze-adventure/internal/engine/config"
+	"github.com/juanancid/maze-adventure/internal/engine/input"
 	"github.com/juanancid/maze-adventure/internal/engine/utils"
+	"github.com/juanancid/maze-adventure/internal/gameplay/achievements"
 	"github.com/juanancid/maze-adventure/internal/gameplay/config"
 	"github.com/juanancid/maze-adventure/internal/gameplay/events"
 	"github.com/juanancid/maze-adventure/internal/gameplay/levels"
+	"github.com/juanancid/maze-adventure/internal/gameplay/save"
 	"github.com/juanancid/maze-adventure/internal/gameplay/session"
+	"github.com/juanancid/maze-adventure/internal/gameplay/snapshot"
 	"github.com/juanancid/maze-adventure/internal/gameplay/systems/renderers"
+	"github.com/juanancid/maze-adventure/internal/gameplay/systems/scheduler"
 	"github.com/juanancid/maze-adventure/internal/gameplay/systems/updaters"
 )
 
@@ -21,13 +32,19 @@ type PlayingState struct {
 	stateManager *Manager
 	levelManager *levels.Manager
 	config       config.GameConfig
+	saves        *save.Store // Where progress is saved at level boundaries, nil disables saving
 
 	gameSession *session.GameSession
+	clock       *clock.Simulation // Gameplay time, only advanced while this state updates
 	eventBus    *events.Bus
-
-	world     *entities.World
-	updaters  []Updater
-	renderers []Renderer
+	input       *input.Handler
+	snapshots   *snapshot.Registry
+
+	levelStart     session.State // Session when the current level started, to restart it
+	levelStartTime time.Duration // Simulation time when the current level started
+	world          *entities.World
+	updaters       *scheduler.Scheduler[Updater]
+	renderers      *scheduler.Scheduler[Renderer]
 }
 
 type Updater interface {
@@ -38,19 +55,72 @@ type Renderer interface {
 	Draw(world *entities.World, gameSession *session.GameSession, screen *ebiten.Image)
 }
 
-func NewPlayingState(stateManager *Manager, levelManager *levels.Manager, config config.GameConfig) *PlayingState {
+func NewPlayingState(stateManager *Manager, levelManager *levels.Manager, gameConfig config.GameConfig, saves *save.Store) *PlayingState {
+	simulation := clock.NewSimulation(engineconfig.TicksPerSecond)
+	return newPlayingState(stateManager, levelManager, gameConfig, saves, simulation, session.NewGameSession(sessionConfig(gameConfig), simulation))
+}
+
+// sessionConfig returns the configuration the session of a new run is played with: time-attack
+// runs are all played at the normal difficulty, so their times can be compared
+func sessionConfig(gameConfig config.GameConfig) config.GameConfig {
+	if gameConfig.Mode == config.ModeTimeAttack {
+		gameConfig.Difficulty = config.Normal()
+	}
+	return gameConfig
+}
+
+// NewResumedPlayingState continues a saved run of the pack at the level it was saved at
+func NewResumedPlayingState(stateManager *Manager, pack *levels.Pack, config config.GameConfig, saves *save.Store, data save.Data) (*PlayingState, error) {
+	levelManager, config, err := savedRunLevelManager(pack, config, data)
+	if err != nil {
+		return nil, err
+	}
+
+	simulation := clock.NewSimulation(engineconfig.TicksPerSecond)
+	gameSession := session.NewGameSession(config, simulation)
+	data.ApplyTo(gameSession)
+
+	return newPlayingState(stateManager, levelManager, config, saves, simulation, gameSession), nil
+}
+
+// NewDailyPlayingState starts the daily challenge of the UTC day of the given time. Everyone
+// playing it that day gets the same levels, played at the normal difficulty.
+func NewDailyPlayingState(stateManager *Manager, pack *levels.Pack, gameConfig config.GameConfig, saves *save.Store, now time.Time) (*PlayingState, error) {
+	levelManager, err := levels.NewDailyManager(pack, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the daily challenge: %w", err)
+	}
+
+	gameConfig.Mode = config.ModeDaily
+	sessionConfig := gameConfig
+	sessionConfig.Seed = levels.DailySeed(now)
+	sessionConfig.Difficulty = config.Normal()
+
+	simulation := clock.NewSimulation(engineconfig.TicksPerSecond)
+	gameSession := session.NewGameSession(sessionConfig, simulation)
+
+	return newPlayingState(stateManager, levelManager, gameConfig, saves, simulation, gameSession), nil
+}
+
+func newPlayingState(stateManager *Manager, levelManager *levels.Manager, config config.GameConfig, saves *save.Store, simulation *clock.Simulation, gameSession *session.GameSession) *PlayingState {
 	ps := &PlayingState{
 		stateManager: stateManager,
 		levelManager: levelManager,
 		config:       config,
-		gameSession:  session.NewGameSession(config),
+		saves:        saves,
+		gameSession:  gameSession,
+		clock:        simulation,
 		eventBus:     events.NewBus(),
+		input:        input.NewHandler(),
+		snapshots:    snapshot.DefaultRegistry(),
 	}
 
+	ps.loadBestSplits()
 	ps.loadNextLevel()
 	ps.setUpdaters()
 	ps.setRenderers()
 	ps.setupEventSubscriptions()
+	ps.trackAchievements()
 
 	return ps
 }
@@ -67,17 +137,58 @@ func (s *PlayingState) OnExit() {
 	utils.StopBackgroundMusic()
 }
 
+// OnPause freezes the simulation and the music while another state is on top, such as the pause menu
+func (s *PlayingState) OnPause() {
+	s.clock.Pause()
+	utils.StopBackgroundMusic()
+}
+
+func (s *PlayingState) OnResume() {
+	s.clock.Resume()
+	utils.ResumeBackgroundMusic()
+}
+
 func (s *PlayingState) Update() error {
-	for _, updater := range s.updaters {
+	// Leaving the window or pressing Esc, P or start pauses the game before anything moves this frame
+	pausePressed := bindPause.justPressed(s.input)
+	if pausePressed || !ebiten.IsFocused() {
+		s.stateManager.PushState(NewPauseState(s.stateManager, s))
+		return nil
+	}
+
+	updateSystems, err := s.updaters.Systems()
+	if err != nil {
+		return fmt.Errorf("failed to schedule updaters: %w", err)
+	}
+	if _, err := s.renderers.Systems(); err != nil {
+		return fmt.Errorf("failed to schedule renderers: %w", err)
+	}
+
+	s.clock.Tick()
+	s.gameSession.Stats.PlayTime += s.clock.Delta()
+	for _, updater := range updateSystems {
 		updater.Update(s.world, s.gameSession)
 	}
 
+	// Apply the changes queued by the updaters before any event handler looks at the world
+	s.world.Flush()
+
 	s.eventBus.Process()
+
+	// Snapshots are taken between frames, once every queued change is applied
+	if s.input.IsKeyJustPressed(ebiten.KeyF5) && s.allowsRetries() {
+		s.quicksave()
+	}
+	if s.input.IsKeyJustPressed(ebiten.KeyF9) && s.allowsRetries() {
+		s.quickload()
+	}
 	return nil
 }
 
 func (s *PlayingState) Draw(screen *ebiten.Image) {
-	for _, renderer := range s.renderers {
+	// Ordering errors are reported by Update
+	renderSystems, _ := s.renderers.Systems()
+	for _, renderer := range renderSystems {
 		renderer.Draw(s.world, s.gameSession, screen)
 	}
 }
@@ -93,8 +204,13 @@ func (s *PlayingState) loadNextLevel() {
 		return
 	}
 
+	// Nothing was played before the first level of a new run
+	newRun := s.gameSession.CurrentLevel == 0 && s.gameSession.Stats == (session.RunStats{})
 	s.gameSession.CurrentLevel = levelNumber
-	world, err := levels.CreateLevel(levelConfig)
+	levelSeed := levels.LevelSeed(levelConfig, s.gameSession.Seed, levelNumber)
+	log.Printf("Loading level %d (run seed %d, level seed %d)", levelNumber, s.gameSession.Seed, levelSeed)
+
+	world, err := levels.CreateLevel(levelConfig, levelSeed, s.gameSession.Config.Difficulty)
 	if err != nil {
 		// Critical error: level creation failed
 		log.Printf("CRITICAL: Failed to create level %d: %v", levelNumber, err)
@@ -112,30 +228,296 @@ func (s *PlayingState) loadNextLevel() {
 	}
 	s.world = world
 
-	// Initialize the timer for this level
+	// Initialize the timer and the stats for this level
+	collectibles := queries.CountScoreCollectibles(world)
 	s.gameSession.SetTimer(levelConfig.Timer)
+	s.gameSession.StartLevel(collectibles)
+	s.levelStart = s.gameSession.State()
+	s.levelStartTime = s.clock.Now()
+	s.eventBus.Publish(events.LevelStarted{Level: levelNumber, Collectibles: collectibles, NewRun: newRun})
+
+	s.saveProgress(levelNumber)
 }
 
-func (s *PlayingState) setUpdaters() {
-	s.updaters = []Updater{
-		updaters.NewInputControl(),
-		updaters.NewEnhancedPatrollerMovement(),
-		updaters.NewMovement(),
-		updaters.NewPatrollerMazeCollision(),
-		updaters.NewMazeCollision(s.eventBus),
-		updaters.NewExitCollision(s.eventBus),
-		updaters.NewCollectiblePickup(s.eventBus),
-		updaters.NewPatrollerCollision(s.eventBus),
-		updaters.NewTimer(s.eventBus),
+// restartLevel builds the current level again and gives back the score it started with.
+// Hearts lost in the level are not given back.
+func (s *PlayingState) restartLevel() {
+	levelConfig, levelNumber, hasLevel := s.levelManager.GetCurrentLevel()
+	if !hasLevel {
+		return
+	}
+
+	levelSeed := levels.LevelSeed(levelConfig, s.gameSession.Seed, levelNumber)
+	world, err := levels.CreateLevel(levelConfig, levelSeed, s.gameSession.Config.Difficulty)
+	if err != nil {
+		log.Printf("Failed to restart level %d: %v", levelNumber, err)
+		return
 	}
+	s.world = world
+
+	// The hearts lost stay lost, and so stay counted
+	hearts := s.gameSession.CurrentHearts
+	runHeartsLost := s.gameSession.Stats.HeartsLost
+	levelHeartsLost := s.gameSession.Level.HeartsLost
+	s.gameSession.Restore(s.levelStart)
+	s.gameSession.CurrentHearts = hearts
+	s.gameSession.Stats.HeartsLost = runHeartsLost
+	s.gameSession.Level.HeartsLost = levelHeartsLost
+	s.gameSession.SetTimer(levelConfig.Timer)
+	s.levelStartTime = s.clock.Now()
+	s.eventBus.Publish(events.LevelStarted{Level: levelNumber, Collectibles: queries.CountScoreCollectibles(world)})
+}
+
+// quitToTitle leaves the run, which can still be continued from the start of the current level.
+// A daily challenge cannot: continuing would be a second attempt at the level, so quitting ends it.
+// A time-attack run keeps the time played in the level, starting it over does not give it back.
+func (s *PlayingState) quitToTitle() {
+	switch s.config.Mode {
+	case config.ModeDaily:
+		s.recordDaily(true)
+		s.clearProgress()
+	case config.ModeTimeAttack:
+		s.saveRunTime()
+	}
+	s.stateManager.FadeTo(NewTitleState(s.stateManager, s.levelManager.Pack(), s.config, s.saves))
+}
+
+// newLevelManager creates a level manager for a new run in the configured mode. Campaigns start
+// at the configured level of the pack, endless and time-attack runs at their first level.
+func newLevelManager(pack *levels.Pack, gameConfig config.GameConfig) *levels.Manager {
+	levelNumber := max(gameConfig.StartingLevel, 1)
+	if gameConfig.Mode == config.ModeEndless || gameConfig.Mode == config.ModeTimeAttack {
+		levelNumber = 1
+	}
+
+	levelManager, err := levelManagerAt(pack, gameConfig.Mode, levelNumber)
+	if err != nil {
+		// Fallback to the first level of the pack if there's an error
+		log.Printf("Starting at the first level of the pack: %v", err)
+		levelManager = levels.NewManagerForPack(pack)
+	}
+	return levelManager
+}
+
+// levelManagerAt creates a level manager for the mode, about to play the given level
+func levelManagerAt(pack *levels.Pack, mode config.Mode, levelNumber int) (*levels.Manager, error) {
+	switch mode {
+	case config.ModeEndless:
+		return levels.NewEndlessManager(pack, levels.DefaultCurve(), levelNumber)
+	case config.ModeDaily:
+		return levels.NewDailyManager(pack, levelNumber)
+	}
+	return levels.NewManagerForPackWithStartingLevel(pack, levelNumber)
+}
+
+// savedRunLevelManager creates the level manager a saved run goes on with, and the configuration
+// of the run: the mode and difficulty it was started with
+func savedRunLevelManager(pack *levels.Pack, gameConfig config.GameConfig, data save.Data) (*levels.Manager, config.GameConfig, error) {
+	gameConfig.Mode = data.Mode
+	if data.Difficulty.Name != "" {
+		gameConfig.Difficulty = data.Difficulty
+	}
+
+	levelManager, err := levelManagerAt(pack, gameConfig.Mode, data.Level)
+	if err != nil {
+		return nil, gameConfig, fmt.Errorf("failed to resume saved run: %w", err)
+	}
+	if data.Pack != levelManager.Name() {
+		return nil, gameConfig, fmt.Errorf("saved run is for %q, not %q", data.Pack, levelManager.Name())
+	}
+	return levelManager, gameConfig, nil
+}
+
+// saveProgress saves the run so it can be continued from the start of the given level
+func (s *PlayingState) saveProgress(levelNumber int) {
+	if s.saves == nil {
+		return
+	}
+
+	data := save.FromSession(s.gameSession, s.levelManager.Name(), levelNumber)
+	if err := s.saves.Save(data); err != nil {
+		log.Printf("Failed to save progress: %v", err)
+	}
+}
+
+// saveRunTime saves the run so it can be continued from the start of the current level, but
+// with the run time it has now
+func (s *PlayingState) saveRunTime() {
+	if s.saves == nil {
+		return
+	}
+
+	data := save.FromSession(s.gameSession, s.levelManager.Name(), s.levelManager.GetCurrentLevelNumber())
+	data.Score = s.levelStart.Score
+	data.CurrentHearts = s.levelStart.CurrentHearts
+	data.Stats = s.levelStart.Stats
+	if err := s.saves.Save(data); err != nil {
+		log.Printf("Failed to save progress: %v", err)
+	}
+}
+
+// quicksave saves a snapshot of the running level
+func (s *PlayingState) quicksave() {
+	if s.saves == nil {
+		return
+	}
+
+	var buf bytes.Buffer
+	level := snapshot.Level{
+		Pack:       s.levelManager.Name(),
+		World:      s.world,
+		Session:    s.gameSession.State(),
+		LevelStart: s.levelStart,
+		LevelTime:  s.clock.Now() - s.levelStartTime,
+	}
+	if err := s.snapshots.Write(&buf, level); err != nil {
+		log.Printf("Failed to quicksave: %v", err)
+		return
+	}
+	if err := s.saves.SaveQuick(buf.Bytes()); err != nil {
+		log.Printf("Failed to quicksave: %v", err)
+		return
+	}
+
+	log.Printf("Quicksaved level %d", s.gameSession.CurrentLevel)
+}
+
+// quickload goes back to the last quicksave. Nothing changes if it cannot be restored.
+func (s *PlayingState) quickload() {
+	if s.saves == nil {
+		return
+	}
+
+	data, err := s.saves.LoadQuick()
+	if errors.Is(err, save.ErrNoSave) {
+		log.Printf("Nothing to quickload")
+		return
+	}
+	if err != nil {
+		log.Printf("Failed to quickload: %v", err)
+		return
+	}
+
+	level, err := s.snapshots.Read(bytes.NewReader(data))
+	if err != nil {
+		log.Printf("Failed to quickload: %v", err)
+		return
+	}
+	if level.Pack != s.levelManager.Name() {
+		log.Printf("Failed to quickload: the quicksave is for level pack %q, not %q", level.Pack, s.levelManager.Name())
+		return
+	}
+	if err := s.levelManager.GoToLevel(level.Session.CurrentLevel); err != nil {
+		log.Printf("Failed to quickload: %v", err)
+		return
+	}
+
+	s.world = level.World
+	s.clock.Set(level.Session.Time)
+	s.gameSession.Restore(level.Session)
+	s.levelStart = level.LevelStart
+	s.levelStartTime = s.clock.Now() - level.LevelTime
+	s.eventBus.Publish(events.LevelStarted{Level: s.gameSession.CurrentLevel, Collectibles: queries.CountScoreCollectibles(s.world)})
+	log.Printf("Quickloaded level %d", s.gameSession.CurrentLevel)
+}
+
+// recordLevel keeps the score and time of the level just completed if they are the best ones
+func (s *PlayingState) recordLevel(playTime time.Duration) {
+	if s.saves == nil {
+		return
+	}
+
+	records, err := s.saves.LoadRecords()
+	if err != nil {
+		log.Printf("Replacing unreadable records: %v", err)
+	}
+
+	score := s.gameSession.Score - s.levelStart.Score
+	if !records.Complete(s.levelManager.Name(), s.gameSession.CurrentLevel, score, playTime) {
+		return
+	}
+	if err := s.saves.SaveRecords(records); err != nil {
+		log.Printf("Failed to save records: %v", err)
+	}
+}
+
+// recordDaily keeps how far the attempt at the daily challenge went, if this run is one
+func (s *PlayingState) recordDaily(finished bool) {
+	if s.saves == nil || s.config.Mode != config.ModeDaily {
+		return
+	}
+
+	records, err := s.saves.LoadRecords()
+	if err != nil {
+		log.Printf("Replacing unreadable records: %v", err)
+	}
+
+	if !records.UpdateDaily(s.gameSession.Seed, s.gameSession.Score, s.gameSession.CurrentLevel, finished) {
+		return
+	}
+	if err := s.saves.SaveRecords(records); err != nil {
+		log.Printf("Failed to save records: %v", err)
+	}
+}
+
+// loadBestSplits gives the run timer of a time-attack run the splits of the personal best of the pack
+func (s *PlayingState) loadBestSplits() {
+	if s.saves == nil || !s.gameSession.RunTimer.Enabled {
+		return
+	}
+
+	records, err := s.saves.LoadRecords()
+	if err != nil {
+		log.Printf("Cannot compare with the personal best: %v", err)
+		return
+	}
+	s.gameSession.RunTimer.Best = records.BestSplits(s.levelManager.Name())
+}
+
+// allowsRetries returns true if levels can be played again: daily challenges are a single attempt,
+// and time-attack runs cannot go back in time
+func (s *PlayingState) allowsRetries() bool {
+	return s.config.Mode != config.ModeDaily && s.config.Mode != config.ModeTimeAttack
+}
+
+// clearProgress deletes the saved run once it is over, there is nothing left to continue
+func (s *PlayingState) clearProgress() {
+	if s.saves == nil {
+		return
+	}
+
+	if err := s.saves.Clear(); err != nil {
+		log.Printf("Failed to clear saved progress: %v", err)
+	}
+}
+
+func (s *PlayingState) setUpdaters() {
+	s.updaters = scheduler.New[Updater](scheduler.UpdatePhases()...)
+
+	mustAdd(s.updaters.Add("input-control", scheduler.PhaseInput, updaters.NewInputControl()))
+	mustAdd(s.updaters.Add("patroller-movement", scheduler.PhaseAI, updaters.NewEnhancedPatrollerMovement(s.clock)))
+	mustAdd(s.updaters.Add("movement", scheduler.PhasePhysics, updaters.NewMovement(s.clock)))
+	mustAdd(s.updaters.Add("patroller-maze-collision", scheduler.PhaseCollision, updaters.NewPatrollerMazeCollision()))
+	mustAdd(s.updaters.Add("maze-collision", scheduler.PhaseCollision, updaters.NewMazeCollision(s.eventBus)))
+	mustAdd(s.updaters.Add("exit-collision", scheduler.PhaseGameplay, updaters.NewExitCollision(s.eventBus)))
+	mustAdd(s.updaters.Add("collectible-pickup", scheduler.PhaseGameplay, updaters.NewCollectiblePickup(s.eventBus)))
+	mustAdd(s.updaters.Add("patroller-collision", scheduler.PhaseGameplay, updaters.NewPatrollerCollision(s.eventBus)))
+	mustAdd(s.updaters.Add("timer", scheduler.PhaseGameplay, updaters.NewTimer(s.eventBus, s.clock), scheduler.After("patroller-collision")))
 }
 
 func (s *PlayingState) setRenderers() {
-	s.renderers = []Renderer{
-		renderers.NewMaze(),
-		renderers.NewSprite(),
-		renderers.NewPatrollerRenderer(),
-		renderers.NewHUD(),
+	s.renderers = scheduler.New[Renderer](scheduler.RenderPhases()...)
+
+	mustAdd(s.renderers.Add("maze", scheduler.PhaseBackground, renderers.NewMaze()))
+	mustAdd(s.renderers.Add("sprites", scheduler.PhaseEntities, renderers.NewSprite()))
+	mustAdd(s.renderers.Add("patrollers", scheduler.PhaseEntities, renderers.NewPatrollerRenderer(), scheduler.After("sprites")))
+	mustAdd(s.renderers.Add("hud", scheduler.PhaseOverlay, renderers.NewHUD()))
+}
+
+// mustAdd panics on registration errors, which are programming mistakes such as a duplicated name
+func mustAdd(err error) {
+	if err != nil {
+		panic(err)
 	}
 }
 
@@ -148,29 +530,81 @@ func (s *PlayingState) setupEventSubscriptions() {
 	s.eventBus.Subscribe(reflect.TypeOf(events.PlayerFrozen{}), s.onPlayerFrozen)
 }
 
+// trackAchievements follows the run to unlock the achievements not unlocked yet
+func (s *PlayingState) trackAchievements() {
+	var unlocked []string
+	if s.saves != nil {
+		records, err := s.saves.LoadRecords()
+		if err != nil {
+			log.Printf("Cannot tell which achievements are unlocked: %v", err)
+		}
+		unlocked = records.UnlockedAchievements()
+	}
+
+	tracker := achievements.NewTracker(achievements.Defaults(), unlocked, s.gameSession.Config, s.unlockAchievement)
+	tracker.Subscribe(s.eventBus)
+}
+
+// unlockAchievement announces the achievement just unlocked and saves it
+func (s *PlayingState) unlockAchievement(achievement achievements.Achievement) {
+	log.Printf("Achievement unlocked: %s", achievement.Title)
+	s.stateManager.Toast("ACHIEVEMENT UNLOCKED", achievement.Title)
+	if s.saves == nil {
+		return
+	}
+
+	records, err := s.saves.LoadRecords()
+	if err != nil {
+		log.Printf("Replacing unreadable records: %v", err)
+	}
+	if !records.UnlockAchievement(achievement.ID, time.Now()) {
+		return
+	}
+	if err := s.saves.SaveRecords(records); err != nil {
+		log.Printf("Failed to save records: %v", err)
+	}
+}
+
 func (s *PlayingState) OnCollectiblePicked(e events.Event) {
 	utils.PlaySound(utils.SoundCollectibleBip)
 	s.gameSession.Score += e.(events.CollectiblePicked).Value
+	s.gameSession.Stats.CollectiblesPicked++
+	s.gameSession.Level.CollectiblesPicked++
 }
 
 func (s *PlayingState) onLevelCompleted(e events.Event) {
 	utils.PlaySound(utils.SoundLevelCompleted)
-	s.loadNextLevel()
+	s.gameSession.Stats.LevelsCompleted++
+	summary := s.gameSession.CompleteLevel(s.clock.Now() - s.levelStartTime)
+	s.recordLevel(summary.PlayTime)
+	s.recordDaily(false)
+
+	// The next level is loaded once the player is done with the summary
+	s.stateManager.PushState(NewLevelSummaryState(s.stateManager, s, summary, s.gameSession.Score))
 }
 
 func (s *PlayingState) onGameCompleted(e events.Event) {
-	victoryState := NewVictoryState(s.stateManager)
-	s.stateManager.ChangeState(victoryState)
+	s.clearProgress()
+	s.eventBus.Publish(events.RunFinished{Victory: true, Level: s.gameSession.CurrentLevel, Score: s.gameSession.Score})
+	s.recordDaily(true)
+	var timeAttack *save.TimeAttackRun
+	if s.gameSession.RunTimer.Enabled {
+		run := save.TimeAttackRunFromSession(s.gameSession)
+		timeAttack = &run
+	}
+	victoryState := NewVictoryState(s.stateManager, s.levelManager.Pack(), s.config, s.saves, save.RunFromSession(s.gameSession, true), s.gameSession.Stats, timeAttack)
+	s.stateManager.FadeTo(victoryState)
 }
 
 func (s *PlayingState) onPlayerDamaged(e events.Event) {
 	// Check if damage can be applied (respects cooldown)
-	if !s.gameSession.CanApplyDamageEffect() {
-		return // Skip damage if in cooldown period
+	amount := e.(events.PlayerDamaged).Amount
+	if amount <= 0 || !s.gameSession.CanApplyDamageEffect() {
+		return // Skip harmless contacts and damage in the cooldown period
 	}
 
 	utils.PlaySound(utils.SoundDamage)
-	s.gameSession.ApplyDamageWithCooldown()
+	s.gameSession.ApplyDamageWithCooldown(amount)
 
 	// If player has no hearts left, game over
 	if !s.gameSession.IsAlive() {
@@ -179,8 +613,20 @@ func (s *PlayingState) onPlayerDamaged(e events.Event) {
 }
 
 func (s *PlayingState) triggerGameOver() {
-	gameOverState := NewGameOverState(s.stateManager)
-	s.stateManager.ChangeState(gameOverState)
+	s.clearProgress()
+	s.eventBus.Publish(events.RunFinished{Victory: false, Level: s.gameSession.CurrentLevel, Score: s.gameSession.Score})
+	s.recordDaily(true)
+	gameOverState := NewGameOverState(s.stateManager, s.levelManager.Pack(), s.config, s.saves, s.retryData(), save.RunFromSession(s.gameSession, false))
+	s.stateManager.FadeTo(gameOverState)
+}
+
+// retryData describes the run at the start of the current level, with every heart back
+func (s *PlayingState) retryData() save.Data {
+	data := save.FromSession(s.gameSession, s.levelManager.Name(), s.levelManager.GetCurrentLevelNumber())
+	data.Score = s.levelStart.Score
+	data.CurrentHearts = data.MaxHearts
+	data.Stats.Retries++
+	return data
 }
 
 func (s *PlayingState) onTimerExpired(e events.Event) {
@@ -0,0 +1,81 @@
+package states
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/juanancid/maze-adventure/internal/engine/input"
+	"github.com/juanancid/maze-adventure/internal/engine/utils"
+)
+
+const (
+	settingsMusic = iota
+	settingsSounds
+)
+
+// SettingsState lets the player turn the music and the sound effects on and off
+type SettingsState struct {
+	manager *Manager
+
+	menu  *menu
+	input *input.Handler
+}
+
+func NewSettingsState(manager *Manager) *SettingsState {
+	s := &SettingsState{
+		manager: manager,
+		input:   input.NewHandler(),
+	}
+
+	s.menu = newMenu(
+		menuItem{action: func() { utils.SetMusicEnabled(!utils.IsMusicEnabled()) }},
+		menuItem{action: func() { utils.SetSoundsEnabled(!utils.IsSoundsEnabled()) }},
+		menuItem{label: "BACK", action: s.back},
+	)
+	s.updateLabels()
+	return s
+}
+
+func (s *SettingsState) OnEnter() {
+	s.menu.reset()
+	bindBack.ignoreHeld(s.input)
+}
+
+func (s *SettingsState) OnExit() {}
+
+func (s *SettingsState) OnPause() {}
+
+func (s *SettingsState) OnResume() {}
+
+func (s *SettingsState) Update() error {
+	if bindBack.justPressed(s.input) {
+		s.back()
+		return nil
+	}
+
+	s.menu.Update()
+	s.updateLabels()
+	return nil
+}
+
+func (s *SettingsState) Draw(screen *ebiten.Image) {
+	screen.Fill(bgColor)
+
+	drawCenteredText(screen, "SETTINGS", 80, titleFontSize)
+	s.menu.Draw(screen, 120)
+}
+
+func (s *SettingsState) updateLabels() {
+	s.menu.setLabel(settingsMusic, "MUSIC: "+onOff(utils.IsMusicEnabled()))
+	s.menu.setLabel(settingsSounds, "SOUND EFFECTS: "+onOff(utils.IsSoundsEnabled()))
+}
+
+func (s *SettingsState) back() {
+	s.manager.PopState()
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "ON"
+	}
+	return "OFF"
+}
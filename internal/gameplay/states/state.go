@@ -7,4 +7,21 @@ type State interface {
 	Draw(screen *ebiten.Image)
 	OnEnter()
 	OnExit()
+	OnPause()  // Another state was pushed on top of this one
+	OnResume() // The state on top of this one was popped
+}
+
+// Layered is implemented by states that do not cover the whole game, such as overlays.
+// States that do not implement it hide and stop the states below them.
+type Layered interface {
+	DrawBelow() bool   // Whether the state below is drawn before this one
+	UpdateBelow() bool // Whether the state below keeps updating while this one is on top
+}
+
+// layers returns the layering flags of the state
+func layers(state State) (drawBelow, updateBelow bool) {
+	if layered, ok := state.(Layered); ok {
+		return layered.DrawBelow(), layered.UpdateBelow()
+	}
+	return false, false
 }
@@ -1,6 +1,9 @@
 package states
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/examples/resources/fonts"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
@@ -39,3 +42,12 @@ func drawCenteredText(screen *ebiten.Image, txt string, y float64, size float64)
 func init() {
 	font = utils.MustLoadGoTextFaceSource(fonts.PressStart2P_ttf)
 }
+
+// formatDuration writes a play time as MM:SS, or H:MM:SS past the hour
+func formatDuration(d time.Duration) string {
+	seconds := int(d.Seconds())
+	if seconds >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", seconds/3600, seconds/60%60, seconds%60)
+	}
+	return fmt.Sprintf("%02d:%02d", seconds/60, seconds%60)
+}
@@ -0,0 +1,242 @@
+package states
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+
+	engineconfig "github.com/juanancid/maze-adventure/internal/engine/config"
+	"github.com/juanancid/maze-adventure/internal/gameplay/config"
+	"github.com/juanancid/maze-adventure/internal/gameplay/levels"
+	"github.com/juanancid/maze-adventure/internal/gameplay/save"
+	"github.com/juanancid/maze-adventure/internal/gameplay/session"
+)
+
+const (
+	titleVisibleRuns = 5                               // How many runs of a leaderboard the title screen shows
+	titleBoardTicks  = 5 * engineconfig.TicksPerSecond // How long every leaderboard is shown before the next one
+)
+
+// leaderboardView is a leaderboard as shown on the title screen
+type leaderboardView struct {
+	title string
+	rows  []string // A line for every run, best first
+}
+
+// TitleState is the main menu, shown after the boot screen and when leaving a run
+type TitleState struct {
+	manager *Manager
+	pack    *levels.Pack
+	config  config.GameConfig
+	saves   *save.Store
+
+	savedRun    *save.Data // Run that can be continued, nil if there is none
+	saveProblem string     // Why the saved run cannot be continued
+	boards      []leaderboardView
+	ticks       int // Updates since the title screen was entered, to take turns showing the leaderboards
+
+	menu            *menu
+	difficultyIndex int // Index of the difficulty option in the menu
+	quit            bool
+}
+
+func NewTitleState(manager *Manager, pack *levels.Pack, config config.GameConfig, saves *save.Store) *TitleState {
+	return &TitleState{
+		manager: manager,
+		pack:    pack,
+		config:  config,
+		saves:   saves,
+	}
+}
+
+func (s *TitleState) OnEnter() {
+	s.loadSavedRun()
+	s.loadLeaderboards()
+	s.ticks = 0
+
+	items := []menuItem{{label: "NEW GAME", action: s.newGame}}
+	if s.savedRun != nil {
+		items = append(items, menuItem{label: "CONTINUE", action: s.continueRun})
+	}
+	items = append(items, menuItem{label: "LEVEL SELECT", action: s.levelSelect})
+	s.difficultyIndex = len(items)
+	items = append(items,
+		menuItem{action: s.nextDifficulty},
+		menuItem{label: "SETTINGS", action: s.settings},
+		menuItem{label: "QUIT", action: func() { s.quit = true }},
+	)
+
+	s.menu = newMenu(items...)
+	s.updateDifficultyLabel()
+	s.menu.reset()
+}
+
+func (s *TitleState) OnExit() {}
+
+func (s *TitleState) OnPause() {}
+
+func (s *TitleState) OnResume() {
+	s.menu.ignoreHeldKeys()
+}
+
+func (s *TitleState) Update() error {
+	s.ticks++
+	s.menu.Update()
+	if s.quit {
+		return ebiten.Termination
+	}
+	return nil
+}
+
+func (s *TitleState) Draw(screen *ebiten.Image) {
+	screen.Fill(bgColor)
+
+	drawCenteredText(screen, "MAZE ADVENTURE", 20, titleFontSize)
+	drawCenteredText(screen, "AVA-002 Main Terminal", 50, regularFontSize)
+
+	s.menu.Draw(screen, 85)
+
+	board := s.boards[s.ticks/titleBoardTicks%len(s.boards)]
+	drawCenteredText(screen, board.title, 180, regularFontSize)
+	for i := range titleVisibleRuns {
+		row := fmt.Sprintf("%d. ---  %7s  %-9s", i+1, "-", "")
+		if i < len(board.rows) {
+			row = fmt.Sprintf("%d. %s", i+1, board.rows[i])
+		}
+		drawCenteredText(screen, row, 195+float64(i*12), regularFontSize)
+	}
+
+	switch {
+	case s.savedRun != nil && s.savedRun.Mode == config.ModeEndless:
+		drawCenteredText(screen, fmt.Sprintf("LAST BOOT: ENDLESS DEPTH %d", s.savedRun.Level), 258, regularFontSize)
+	case s.savedRun != nil && s.savedRun.Mode == config.ModeDaily:
+		drawCenteredText(screen, fmt.Sprintf("LAST BOOT: DAILY SECTOR %d", s.savedRun.Level), 258, regularFontSize)
+	case s.savedRun != nil && s.savedRun.Mode == config.ModeTimeAttack:
+		drawCenteredText(screen, fmt.Sprintf("LAST BOOT: TIME ATTACK SECTOR %d", s.savedRun.Level), 258, regularFontSize)
+	case s.savedRun != nil:
+		drawCenteredText(screen, fmt.Sprintf("LAST BOOT: SECTOR %d", s.savedRun.Level), 258, regularFontSize)
+	case s.saveProblem != "":
+		drawCenteredText(screen, s.saveProblem, 258, regularFontSize)
+	default:
+		drawCenteredText(screen, "LAST BOOT: UNKNOWN", 258, regularFontSize)
+	}
+}
+
+// loadLeaderboards reads the best runs of the pack, of endless runs, of daily challenges and
+// of time-attack runs to show them. The leaderboards are empty if the records cannot be read.
+func (s *TitleState) loadLeaderboards() {
+	records := save.NewRecords()
+	if s.saves != nil {
+		var err error
+		if records, err = s.saves.LoadRecords(); err != nil {
+			log.Printf("Cannot show high scores: %v", err)
+		}
+	}
+
+	s.boards = []leaderboardView{
+		{title: "HIGH SCORES", rows: scoreRows(records.Runs(s.pack.Name), "SECTOR")},
+		{title: "ENDLESS HIGH SCORES", rows: scoreRows(records.Runs(levels.EndlessName), "DEPTH")},
+		{title: "DAILY CHALLENGE HIGH SCORES", rows: scoreRows(records.Runs(levels.DailyName), "SECTOR")},
+		{title: "TIME ATTACK BEST TIMES", rows: timeRows(records.TimeAttackRuns(s.pack.Name))},
+	}
+}
+
+// scoreRows writes the runs of a leaderboard, with the level they reached called depth
+func scoreRows(runs []save.RunRecord, depth string) []string {
+	rows := make([]string, len(runs))
+	for i, run := range runs {
+		rows[i] = fmt.Sprintf("%s  %7d  %-6s %2d", run.Initials, run.Score, depth, run.Level)
+	}
+	return rows
+}
+
+// timeRows writes the fastest time-attack runs, with the day they were played
+func timeRows(runs []save.TimeAttackRun) []string {
+	rows := make([]string, len(runs))
+	for i, run := range runs {
+		rows[i] = fmt.Sprintf("%s  %s", session.FormatRunTime(run.Time), run.Date.Format(time.DateOnly))
+	}
+	return rows
+}
+
+// loadSavedRun looks for a run to continue. Unusable saves are reported on screen and
+// are overwritten by the next run.
+func (s *TitleState) loadSavedRun() {
+	s.savedRun = nil
+	s.saveProblem = ""
+	if s.saves == nil {
+		return
+	}
+
+	data, err := s.saves.Load()
+	switch {
+	case errors.Is(err, save.ErrNoSave):
+		return
+	case errors.Is(err, save.ErrVersion):
+		s.saveProblem = "SAVE DATA: INCOMPATIBLE VERSION"
+	case errors.Is(err, save.ErrCorrupt):
+		s.saveProblem = "SAVE DATA: CORRUPTED"
+	case err != nil:
+		s.saveProblem = "SAVE DATA: UNREADABLE"
+	default:
+		if _, _, err = savedRunLevelManager(s.pack, s.config, data); err != nil {
+			s.saveProblem = "SAVE DATA: OTHER SECTOR MAP"
+			break
+		}
+		s.savedRun = &data
+		return
+	}
+
+	log.Printf("Cannot continue the saved run: %v", err)
+}
+
+func (s *TitleState) newGame() {
+	s.manager.PushState(NewModeSelectState(s.manager, s.pack, s.config, s.saves))
+}
+
+func (s *TitleState) continueRun() {
+	playingState, err := NewResumedPlayingState(s.manager, s.pack, s.config, s.saves, *s.savedRun)
+	if err != nil {
+		log.Printf("Cannot continue the saved run: %v", err)
+		return
+	}
+	s.manager.FadeTo(playingState)
+}
+
+// difficulties returns the presets and the custom difficulty, in the order the menu goes through them
+func (s *TitleState) difficulties() []config.Difficulty {
+	custom := s.config.CustomDifficulty.OrNormal()
+	custom.Name = config.DifficultyCustom
+	return append(config.Presets(), custom)
+}
+
+// nextDifficulty moves on to the next difficulty, for the runs started from now on
+func (s *TitleState) nextDifficulty() {
+	difficulties := s.difficulties()
+	next := 0
+	for i, difficulty := range difficulties {
+		if difficulty.Name == s.config.Difficulty.Name {
+			next = (i + 1) % len(difficulties)
+			break
+		}
+	}
+
+	s.config.Difficulty = difficulties[next]
+	s.updateDifficultyLabel()
+}
+
+func (s *TitleState) updateDifficultyLabel() {
+	s.menu.setLabel(s.difficultyIndex, "DIFFICULTY: "+strings.ToUpper(s.config.Difficulty.OrNormal().Name))
+}
+
+func (s *TitleState) levelSelect() {
+	s.manager.PushState(NewLevelSelectState(s.manager, s.pack, s.config, s.saves))
+}
+
+func (s *TitleState) settings() {
+	s.manager.PushState(NewSettingsState(s.manager))
+}
@@ -0,0 +1,54 @@
+package states
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/juanancid/maze-adventure/internal/engine/config"
+)
+
+const (
+	toastTicks  = 3 * config.TicksPerSecond // How long a toast stays on screen
+	toastWidth  = 280
+	toastHeight = 34
+	toastY      = config.HudHeight + 6
+)
+
+// toast is a short announcement shown over whatever is on screen
+type toast struct {
+	title string
+	text  string
+	ticks int // Updates since it was first shown
+}
+
+// Toast announces something over every state, even while they change. Toasts are shown
+// one after the other, in the order they were announced.
+func (m *Manager) Toast(title, text string) {
+	m.toasts = append(m.toasts, toast{title: title, text: text})
+}
+
+// updateToasts moves the toast on screen on, and takes it down once it was shown long enough
+func (m *Manager) updateToasts() {
+	if len(m.toasts) == 0 {
+		return
+	}
+
+	m.toasts[0].ticks++
+	if m.toasts[0].ticks >= toastTicks {
+		m.toasts = m.toasts[1:]
+	}
+}
+
+// drawToast draws the toast on screen, if any
+func (m *Manager) drawToast(screen *ebiten.Image) {
+	if len(m.toasts) == 0 {
+		return
+	}
+
+	t := m.toasts[0]
+	x := float32(config.ScreenWidth-toastWidth) / 2
+	vector.DrawFilledRect(screen, x, toastY, toastWidth, toastHeight, bgColor, false)
+	vector.StrokeRect(screen, x, toastY, toastWidth, toastHeight, 1, textColor, false)
+	drawCenteredText(screen, t.title, toastY+11, regularFontSize)
+	drawCenteredText(screen, t.text, toastY+24, regularFontSize)
+}
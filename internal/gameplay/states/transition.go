@@ -0,0 +1,84 @@
+package states
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	"github.com/juanancid/maze-adventure/internal/engine/config"
+)
+
+const defaultTransitionTicks = 30 // Half a second
+
+// TransitionEffect covers the screen while the manager changes states
+type TransitionEffect interface {
+	// Ticks returns how many updates the effect lasts. The screen is fully covered half way through.
+	Ticks() int
+	// Draw covers the screen for the given progress, from 0 to 1
+	Draw(screen *ebiten.Image, progress float64)
+}
+
+type runningTransition struct {
+	effect  TransitionEffect
+	change  func()
+	ticks   int
+	changed bool
+}
+
+func (t *runningTransition) draw(screen *ebiten.Image) {
+	t.effect.Draw(screen, min(float64(t.ticks)/float64(max(t.effect.Ticks(), 1)), 1))
+}
+
+// Fade darkens the screen to a color and brightens it back
+type Fade struct {
+	ticks int
+	color color.RGBA
+}
+
+// NewFade creates a fade to the background color lasting the given number of updates
+func NewFade(ticks int) *Fade {
+	return &Fade{ticks: ticks, color: bgColor}
+}
+
+func (f *Fade) Ticks() int {
+	return f.ticks
+}
+
+func (f *Fade) Draw(screen *ebiten.Image, progress float64) {
+	coverage := 1 - math.Abs(2*progress-1)
+
+	// The color is premultiplied by its alpha, so every channel fades along with it
+	c := color.RGBA{
+		R: uint8(float64(f.color.R) * coverage),
+		G: uint8(float64(f.color.G) * coverage),
+		B: uint8(float64(f.color.B) * coverage),
+		A: uint8(float64(f.color.A) * coverage),
+	}
+	vector.DrawFilledRect(screen, 0, 0, config.ScreenWidth, config.ScreenHeight, c, false)
+}
+
+// Wipe slides a curtain of a color over the screen from left to right, and on out to the right
+type Wipe struct {
+	ticks int
+	color color.RGBA
+}
+
+// NewWipe creates a wipe in the background color lasting the given number of updates
+func NewWipe(ticks int) *Wipe {
+	return &Wipe{ticks: ticks, color: bgColor}
+}
+
+func (w *Wipe) Ticks() int {
+	return w.ticks
+}
+
+func (w *Wipe) Draw(screen *ebiten.Image, progress float64) {
+	left, right := 0.0, 2*progress
+	if progress > 0.5 {
+		left, right = 2*progress-1, 1
+	}
+
+	vector.DrawFilledRect(screen, float32(left*config.ScreenWidth), 0, float32((right-left)*config.ScreenWidth), config.ScreenHeight, w.color, false)
+}
@@ -1,43 +1,88 @@
 package states
 
 import (
-	"os"
+	"fmt"
+	"log"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+
+	"github.com/juanancid/maze-adventure/internal/gameplay/config"
+	"github.com/juanancid/maze-adventure/internal/gameplay/levels"
+	"github.com/juanancid/maze-adventure/internal/gameplay/save"
+	"github.com/juanancid/maze-adventure/internal/gameplay/session"
 )
 
 type VictoryState struct {
 	manager *Manager
+	pack    *levels.Pack
+	config  config.GameConfig
+	saves   *save.Store
+
+	run   save.RunRecord
+	stats session.RunStats
 
-	blinkTimer int
-	blinkOn    bool
+	timeAttack   *save.TimeAttackRun // Run timed in time-attack runs, nil otherwise
+	previousBest time.Duration       // Time of the personal best before this run, 0 if there was none
+	newBest      bool
+
+	menu     *menu
+	initials *initialsEntry // Asks for initials before the menu if the run makes it to the leaderboard
 }
 
-func NewVictoryState(manager *Manager) *VictoryState {
-	return &VictoryState{
+func NewVictoryState(manager *Manager, pack *levels.Pack, gameConfig config.GameConfig, saves *save.Store, run save.RunRecord, stats session.RunStats, timeAttack *save.TimeAttackRun) *VictoryState {
+	s := &VictoryState{
 		manager: manager,
+		pack:    pack,
+		config:  gameConfig,
+		saves:   saves,
+		run:     run,
+		stats:   stats,
+
+		timeAttack: timeAttack,
 	}
+
+	// A daily challenge is a single attempt
+	var items []menuItem
+	if s.config.Mode != config.ModeDaily {
+		items = append(items, menuItem{label: "NEW RUN", action: s.newRun})
+	}
+	items = append(items, menuItem{label: "TITLE", action: s.title})
+
+	s.menu = newMenu(items...)
+	return s
 }
 
 func (s *VictoryState) OnEnter() {
-	s.blinkTimer = 0
-	s.blinkOn = false
+	// Time-attack runs are ranked by time, not on the high scores
+	if s.timeAttack != nil {
+		s.recordTimeAttack()
+	} else {
+		s.initials = offerLeaderboard(s.saves, leaderboardName(s.pack, s.config), s.run)
+	}
+	s.menu.reset()
 }
 
 func (s *VictoryState) OnExit() {
 	// Cleanup explicitly, if needed
 }
 
+func (s *VictoryState) OnPause() {}
+
+func (s *VictoryState) OnResume() {}
+
 func (s *VictoryState) Update() error {
-	s.blinkTimer++
-	if s.blinkTimer >= 60 {
-		s.blinkTimer = 0
-		s.blinkOn = !s.blinkOn
+	if s.initials != nil {
+		s.initials.Update()
+		if s.initials.done {
+			recordRun(s.saves, leaderboardName(s.pack, s.config), s.run, s.initials.initials())
+			s.initials = nil
+			s.menu.reset()
+		}
+		return nil
 	}
 
-	if ebiten.IsKeyPressed(ebiten.KeyEscape) {
-		os.Exit(0)
-	}
+	s.menu.Update()
 	return nil
 }
 
@@ -48,14 +93,65 @@ func (s *VictoryState) Draw(screen *ebiten.Image) {
 	drawCenteredText(screen, "Final Protocol Completed", 50, titleFontSize)
 	drawCenteredText(screen, "AVA-002: Codename Picatoste", 80, titleFontSize)
 
-	drawCenteredText(screen, "All sectors explored.", 120, regularFontSize)
-	drawCenteredText(screen, "Memory integrity stabilized.", 135, regularFontSize)
-	drawCenteredText(screen, "No further instructions received.", 150, regularFontSize)
+	drawCenteredText(screen, fmt.Sprintf("SECTORS EXPLORED: %d", s.stats.LevelsCompleted), 115, regularFontSize)
+	drawCenteredText(screen, fmt.Sprintf("DATA FRAGMENTS RECOVERED: %d", s.stats.CollectiblesPicked), 130, regularFontSize)
+	drawCenteredText(screen, fmt.Sprintf("SCORE: %d", s.run.Score), 145, regularFontSize)
+	drawCenteredText(screen, fmt.Sprintf("HEARTS LOST: %d   RETRIES: %d", s.stats.HeartsLost, s.stats.Retries), 160, regularFontSize)
+	if s.timeAttack != nil {
+		s.drawRunTime(screen)
+	} else {
+		drawCenteredText(screen, "TIME: "+formatDuration(s.stats.PlayTime), 175, regularFontSize)
+	}
+
+	if s.initials != nil {
+		drawCenteredText(screen, "HIGH SCORE! ENTER YOUR INITIALS", 200, regularFontSize)
+		s.initials.Draw(screen, 222)
+		return
+	}
+	s.menu.Draw(screen, 210)
+}
+
+// drawRunTime shows the time of a time-attack run and how it compares with the personal best
+func (s *VictoryState) drawRunTime(screen *ebiten.Image) {
+	drawCenteredText(screen, "RUN TIME: "+session.FormatRunTime(s.timeAttack.Time), 175, regularFontSize)
+
+	switch {
+	case s.newBest:
+		drawCenteredText(screen, "NEW PERSONAL BEST!", 190, regularFontSize)
+	case s.previousBest > 0:
+		delta := session.FormatSplitDelta(s.timeAttack.Time - s.previousBest)
+		drawCenteredText(screen, fmt.Sprintf("PERSONAL BEST: %s (%s)", session.FormatRunTime(s.previousBest), delta), 190, regularFontSize)
+	}
+}
+
+// recordTimeAttack keeps the run if it is one of the fastest of the pack
+func (s *VictoryState) recordTimeAttack() {
+	if s.saves == nil {
+		return
+	}
 
-	drawCenteredText(screen, "SYSTEM SHUTDOWN", 200, regularFontSize)
-	drawCenteredText(screen, "Thank you for playing.", 215, regularFontSize)
+	records, err := s.saves.LoadRecords()
+	if err != nil {
+		log.Printf("Replacing unreadable records: %v", err)
+	}
 
-	if s.blinkOn {
-		drawCenteredText(screen, "Press ESC to disconnect…", 250, regularFontSize)
+	if runs := records.TimeAttackRuns(s.pack.Name); len(runs) > 0 {
+		s.previousBest = runs[0].Time
+	}
+	position := records.AddTimeAttackRun(s.pack.Name, *s.timeAttack)
+	if position < 0 {
+		return
 	}
+	s.newBest = position == 0
+	if err := s.saves.SaveRecords(records); err != nil {
+		log.Printf("Failed to save records: %v", err)
+	}
+}
+
+func (s *VictoryState) newRun() {
+	s.manager.FadeTo(NewPlayingState(s.manager, newLevelManager(s.pack, s.config), s.config, s.saves))
+}
+
+func (s *VictoryState) title() {
+	s.manager.FadeTo(NewTitleState(s.manager, s.pack, s.config, s.saves))
 }
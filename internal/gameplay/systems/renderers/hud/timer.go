@@ -10,7 +10,7 @@ import (
 	"github.com/juanancid/maze-adventure/internal/gameplay/session"
 )
 
-// TimerRenderer handles drawing the level timer
+// TimerRenderer handles drawing the level timer, or the run timer and its splits in time-attack runs
 type TimerRenderer struct {
 	faceSource *text.GoTextFaceSource
 }
@@ -22,6 +22,10 @@ func NewTimerRenderer(faceSource *text.GoTextFaceSource) *TimerRenderer {
 }
 
 func (r *TimerRenderer) Draw(gameSession *session.GameSession, screen *ebiten.Image) {
+	if gameSession.RunTimer.Enabled {
+		r.drawRunTimer(gameSession, screen)
+		return
+	}
 	if !gameSession.TimerEnabled {
 		return
 	}
@@ -47,3 +51,31 @@ func (r *TimerRenderer) Draw(gameSession *session.GameSession, screen *ebiten.Im
 		timerOp,
 	)
 }
+
+// drawRunTimer draws the run timer with the difference to the personal best under it,
+// green when ahead and red when behind
+func (r *TimerRenderer) drawRunTimer(gameSession *session.GameSession, screen *ebiten.Image) {
+	face := &text.GoTextFace{
+		Source: r.faceSource,
+		Size:   8,
+	}
+
+	timerOp := &text.DrawOptions{}
+	timerOp.GeoM.Translate(float64(config.ScreenWidth/2+20), float64(config.HudHeight/2-10))
+	timerOp.ColorScale.ScaleWithColor(color.White)
+	text.Draw(screen, gameSession.GetRunTimerDisplayTime(), face, timerOp)
+
+	split, compared := gameSession.SplitComparison()
+	if !compared {
+		return
+	}
+
+	deltaOp := &text.DrawOptions{}
+	deltaOp.GeoM.Translate(float64(config.ScreenWidth/2+20), float64(config.HudHeight/2+2))
+	if split.Delta > 0 {
+		deltaOp.ColorScale.ScaleWithColor(color.RGBA{R: 255, G: 100, B: 100, A: 255})
+	} else {
+		deltaOp.ColorScale.ScaleWithColor(color.RGBA{R: 100, G: 255, B: 100, A: 255})
+	}
+	text.Draw(screen, session.FormatSplitDelta(split.Delta), face, deltaOp)
+}
@@ -6,8 +6,8 @@ import (
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
 
-	"github.com/juanancid/maze-adventure/internal/core/components"
 	"github.com/juanancid/maze-adventure/internal/core/entities"
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
 	"github.com/juanancid/maze-adventure/internal/core/queries"
 	"github.com/juanancid/maze-adventure/internal/engine/config"
 	"github.com/juanancid/maze-adventure/internal/gameplay/session"
@@ -20,7 +20,7 @@ func NewMaze() Maze {
 }
 
 // getCellColor returns the color for a cell based on its type
-func getCellColor(cell components.Cell) color.RGBA {
+func getCellColor(cell mazelayout.Cell) color.RGBA {
 	if cell.IsDeadly() {
 		return color.RGBA{R: 0xFF, G: 0x00, B: 0x00, A: 0xFF} // Red for deadly
 	} else if cell.IsFreezing() {
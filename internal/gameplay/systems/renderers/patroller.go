@@ -2,7 +2,6 @@ package renderers
 
 import (
 	"image/color"
-	"reflect"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
@@ -13,11 +12,6 @@ import (
 	"github.com/juanancid/maze-adventure/internal/gameplay/session"
 )
 
-// getComponentType is a helper function to get the reflect.Type of a component
-func getComponentType(component interface{}) reflect.Type {
-	return reflect.TypeOf(component)
-}
-
 // PatrollerRenderer renders patroller NPCs in the game
 type PatrollerRenderer struct{}
 
@@ -29,20 +23,8 @@ func NewPatrollerRenderer() PatrollerRenderer {
 // Draw renders all patroller entities
 func (pr PatrollerRenderer) Draw(world *entities.World, gameSession *session.GameSession, screen *ebiten.Image) {
 	// Query for entities that have Patroller, Position, and Size components
-	patrollerEntities := world.QueryComponents(&components.Patroller{}, &components.Position{}, &components.Size{})
-
-	for _, entity := range patrollerEntities {
-		patrollerComp := world.GetComponent(entity, getComponentType(&components.Patroller{}))
-		positionComp := world.GetComponent(entity, getComponentType(&components.Position{}))
-		sizeComp := world.GetComponent(entity, getComponentType(&components.Size{}))
-
-		if patrollerComp == nil || positionComp == nil || sizeComp == nil {
-			continue // Skip if any component is missing
-		}
-
-		patroller := patrollerComp.(*components.Patroller)
-		position := positionComp.(*components.Position)
-		size := sizeComp.(*components.Size)
+	for _, patrollerEntity := range entities.Query3[components.Patroller, components.Position, components.Size](world) {
+		patroller, position, size := patrollerEntity.A, patrollerEntity.B, patrollerEntity.C
 
 		// Only render active patrollers
 		if !patroller.IsPatrollerActive() {
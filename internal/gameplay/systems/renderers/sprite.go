@@ -1,8 +1,6 @@
 package renderers
 
 import (
-	"reflect"
-
 	"github.com/hajimehoshi/ebiten/v2"
 
 	"github.com/juanancid/maze-adventure/internal/core/components"
@@ -18,21 +16,8 @@ func NewSprite() Sprite {
 }
 
 func (r Sprite) Draw(world *entities.World, gameSession *session.GameSession, screen *ebiten.Image) {
-	positions := world.GetComponents(reflect.TypeOf(&components.Position{}))
-	sprites := world.GetComponents(reflect.TypeOf(&components.Sprite{}))
-	sizes := world.GetComponents(reflect.TypeOf(&components.Size{}))
-
-	for entity, pos := range positions {
-		position := pos.(*components.Position)
-		spriteComp, ok := sprites[entity].(*components.Sprite)
-		if !ok {
-			continue
-		}
-
-		sizeComp, ok := sizes[entity].(*components.Size)
-		if !ok {
-			continue
-		}
+	for _, spriteEntity := range entities.Query3[components.Position, components.Sprite, components.Size](world) {
+		position, spriteComp, sizeComp := spriteEntity.A, spriteEntity.B, spriteEntity.C
 
 		// Check if image is nil to prevent crashes
 		if spriteComp.Image == nil {
@@ -0,0 +1,283 @@
+// Package scheduler orders systems by the phase they run in and the before/after
+// constraints they declare, so adding a system does not mean editing a hand-ordered list.
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Phase groups systems that run at the same stage of a frame
+type Phase string
+
+// Update phases, in the order they run
+const (
+	PhaseInput     Phase = "input"     // Read player input
+	PhaseAI        Phase = "ai"        // Decide where non-player entities go
+	PhasePhysics   Phase = "physics"   // Move entities
+	PhaseCollision Phase = "collision" // Resolve collisions with the maze
+	PhaseGameplay  Phase = "gameplay"  // Apply game rules: pickups, damage, exits, timers
+	PhasePost      Phase = "post"      // Bookkeeping once the frame is settled
+)
+
+// Render phases, in the order they run
+const (
+	PhaseBackground Phase = "background" // Maze and static scenery
+	PhaseEntities   Phase = "entities"   // Player, pickups and enemies
+	PhaseOverlay    Phase = "overlay"    // HUD and anything drawn on top
+)
+
+// UpdatePhases returns the update phases in the order they run
+func UpdatePhases() []Phase {
+	return []Phase{PhaseInput, PhaseAI, PhasePhysics, PhaseCollision, PhaseGameplay, PhasePost}
+}
+
+// RenderPhases returns the render phases in the order they run
+func RenderPhases() []Phase {
+	return []Phase{PhaseBackground, PhaseEntities, PhaseOverlay}
+}
+
+var (
+	// ErrCycle is returned when the ordering constraints of the systems form a cycle
+	ErrCycle = errors.New("systems ordering cycle")
+	// ErrUnknownSystem is returned when a system or a constraint refers to a name that was never added
+	ErrUnknownSystem = errors.New("unknown system")
+)
+
+// Constraint orders a system relative to another one
+type Constraint struct {
+	before bool
+	other  string
+}
+
+// Before makes the system run before the named one
+func Before(name string) Constraint {
+	return Constraint{before: true, other: name}
+}
+
+// After makes the system run after the named one
+func After(name string) Constraint {
+	return Constraint{before: false, other: name}
+}
+
+type entry[S any] struct {
+	name        string
+	phase       int
+	system      S
+	enabled     bool
+	constraints []Constraint
+}
+
+// Scheduler keeps a set of named systems of type S, such as updaters or renderers,
+// and resolves the order they run in
+type Scheduler[S any] struct {
+	phases  []Phase
+	entries []*entry[S]
+	byName  map[string]*entry[S]
+
+	order    []*entry[S] // Resolved order of every system, nil when it must be resolved again
+	orderErr error
+	enabled  []S
+}
+
+// New creates a scheduler whose systems run in the given phases, in that order
+func New[S any](phases ...Phase) *Scheduler[S] {
+	return &Scheduler[S]{
+		phases: phases,
+		byName: make(map[string]*entry[S]),
+	}
+}
+
+// Add registers an enabled system. Systems of the same phase without constraints
+// between them run in the order they were added.
+func (s *Scheduler[S]) Add(name string, phase Phase, system S, constraints ...Constraint) error {
+	if _, exists := s.byName[name]; exists {
+		return fmt.Errorf("system %q is already registered", name)
+	}
+
+	phaseIndex := slices.Index(s.phases, phase)
+	if phaseIndex < 0 {
+		return fmt.Errorf("system %q: unknown phase %q", name, phase)
+	}
+
+	e := &entry[S]{
+		name:        name,
+		phase:       phaseIndex,
+		system:      system,
+		enabled:     true,
+		constraints: constraints,
+	}
+	s.entries = append(s.entries, e)
+	s.byName[name] = e
+	s.order = nil
+
+	return nil
+}
+
+// Enable makes a registered system run again
+func (s *Scheduler[S]) Enable(name string) error {
+	return s.setEnabled(name, true)
+}
+
+// Disable keeps a registered system from running. It keeps its place in the order.
+func (s *Scheduler[S]) Disable(name string) error {
+	return s.setEnabled(name, false)
+}
+
+// IsEnabled returns true if the system is registered and enabled
+func (s *Scheduler[S]) IsEnabled(name string) bool {
+	e, exists := s.byName[name]
+	return exists && e.enabled
+}
+
+func (s *Scheduler[S]) setEnabled(name string, enabled bool) error {
+	e, exists := s.byName[name]
+	if !exists {
+		return fmt.Errorf("%w: %q", ErrUnknownSystem, name)
+	}
+
+	if e.enabled != enabled {
+		e.enabled = enabled
+		s.enabled = nil
+	}
+	return nil
+}
+
+// Systems returns the enabled systems in the order they must run.
+// The order is resolved again only when systems are added.
+func (s *Scheduler[S]) Systems() ([]S, error) {
+	if s.order == nil {
+		s.order, s.orderErr = s.resolve()
+		s.enabled = nil
+	}
+	if s.orderErr != nil {
+		return nil, s.orderErr
+	}
+
+	if s.enabled == nil {
+		s.enabled = make([]S, 0, len(s.order))
+		for _, e := range s.order {
+			if e.enabled {
+				s.enabled = append(s.enabled, e.system)
+			}
+		}
+	}
+
+	return s.enabled, nil
+}
+
+// resolve sorts the systems topologically. Phases add an edge from every system to
+// the systems of later phases, and ties are broken by registration order.
+func (s *Scheduler[S]) resolve() ([]*entry[S], error) {
+	successors := make(map[*entry[S]][]*entry[S])
+	predecessors := make(map[*entry[S]]int)
+
+	addEdge := func(from, to *entry[S]) error {
+		if from.phase > to.phase {
+			return fmt.Errorf("%w: %q must run before %q but its phase %q comes after %q",
+				ErrCycle, from.name, to.name, s.phases[from.phase], s.phases[to.phase])
+		}
+		successors[from] = append(successors[from], to)
+		predecessors[to]++
+		return nil
+	}
+
+	for _, e := range s.entries {
+		for _, c := range e.constraints {
+			other, exists := s.byName[c.other]
+			if !exists {
+				return nil, fmt.Errorf("%w: %q is referenced by %q", ErrUnknownSystem, c.other, e.name)
+			}
+
+			from, to := other, e
+			if c.before {
+				from, to = e, other
+			}
+			if err := addEdge(from, to); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	order := make([]*entry[S], 0, len(s.entries))
+	placed := make(map[*entry[S]]bool, len(s.entries))
+	for len(order) < len(s.entries) {
+		next := s.nextReady(placed, predecessors)
+		if next == nil {
+			return nil, s.cycleError(placed, successors)
+		}
+
+		placed[next] = true
+		order = append(order, next)
+		for _, successor := range successors[next] {
+			predecessors[successor]--
+		}
+	}
+
+	return order, nil
+}
+
+// nextReady returns the earliest registered system of the lowest phase with no pending predecessors
+func (s *Scheduler[S]) nextReady(placed map[*entry[S]]bool, predecessors map[*entry[S]]int) *entry[S] {
+	var next *entry[S]
+	for _, e := range s.entries {
+		if placed[e] || predecessors[e] > 0 {
+			continue
+		}
+		if next == nil || e.phase < next.phase {
+			next = e
+		}
+	}
+
+	// A system of a lower phase still waiting on a constraint holds back the later phases
+	for _, e := range s.entries {
+		if next != nil && !placed[e] && e.phase < next.phase {
+			return nil
+		}
+	}
+
+	return next
+}
+
+// cycleError describes one of the cycles among the systems that could not be placed
+func (s *Scheduler[S]) cycleError(placed map[*entry[S]]bool, successors map[*entry[S]][]*entry[S]) error {
+	visiting := make(map[*entry[S]]int) // Position in the current path + 1
+	var path []*entry[S]
+
+	var visit func(e *entry[S]) []*entry[S]
+	visit = func(e *entry[S]) []*entry[S] {
+		if position := visiting[e]; position > 0 {
+			return append(slices.Clone(path[position-1:]), e)
+		}
+		if placed[e] {
+			return nil
+		}
+
+		path = append(path, e)
+		visiting[e] = len(path)
+		for _, successor := range successors[e] {
+			if cycle := visit(successor); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		visiting[e] = 0
+		placed[e] = true // Fully explored without finding a cycle
+
+		return nil
+	}
+
+	for _, e := range s.entries {
+		if cycle := visit(e); cycle != nil {
+			names := make([]string, len(cycle))
+			for i, c := range cycle {
+				names[i] = c.name
+			}
+			return fmt.Errorf("%w: %s", ErrCycle, strings.Join(names, " -> "))
+		}
+	}
+
+	return ErrCycle
+}
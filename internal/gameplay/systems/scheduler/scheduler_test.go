@@ -0,0 +1,227 @@
+package scheduler
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+type system struct {
+	name        string
+	phase       Phase
+	constraints []Constraint
+}
+
+// newScheduler registers every system under its own name, failing the test on any error
+func newScheduler(t *testing.T, systems ...system) *Scheduler[string] {
+	t.Helper()
+
+	s := New[string](UpdatePhases()...)
+	for _, sys := range systems {
+		if err := s.Add(sys.name, sys.phase, sys.name, sys.constraints...); err != nil {
+			t.Fatalf("Add(%q): %v", sys.name, err)
+		}
+	}
+	return s
+}
+
+func checkOrder(t *testing.T, s *Scheduler[string], want ...string) {
+	t.Helper()
+
+	got, err := s.Systems()
+	if err != nil {
+		t.Fatalf("Systems: %v", err)
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Systems = %v, want %v", got, want)
+	}
+}
+
+func TestSystemsRunInPhaseOrder(t *testing.T) {
+	s := newScheduler(t,
+		system{name: "exit", phase: PhaseGameplay},
+		system{name: "movement", phase: PhasePhysics},
+		system{name: "input", phase: PhaseInput},
+		system{name: "pickup", phase: PhaseGameplay},
+		system{name: "patrol", phase: PhaseAI},
+	)
+
+	// Systems of the same phase keep the order they were added in
+	checkOrder(t, s, "input", "patrol", "movement", "exit", "pickup")
+}
+
+func TestConstraintsOrderSystemsWithinAPhase(t *testing.T) {
+	s := newScheduler(t,
+		system{name: "exit", phase: PhaseGameplay, constraints: []Constraint{After("damage")}},
+		system{name: "damage", phase: PhaseGameplay},
+		system{name: "pickup", phase: PhaseGameplay, constraints: []Constraint{Before("damage")}},
+		system{name: "timer", phase: PhaseGameplay, constraints: []Constraint{After("pickup"), Before("exit")}},
+		system{name: "bookkeeping", phase: PhasePost, constraints: []Constraint{After("exit")}},
+	)
+
+	checkOrder(t, s, "pickup", "damage", "timer", "exit", "bookkeeping")
+}
+
+func TestCycleReportsThePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		systems []system
+		want    string
+	}{
+		{
+			name: "three systems",
+			systems: []system{
+				{name: "a", phase: PhaseGameplay, constraints: []Constraint{Before("b")}},
+				{name: "b", phase: PhaseGameplay, constraints: []Constraint{Before("c")}},
+				{name: "c", phase: PhaseGameplay, constraints: []Constraint{Before("a")}},
+			},
+			want: "systems ordering cycle: a -> b -> c -> a",
+		},
+		{
+			name: "after itself",
+			systems: []system{
+				{name: "free", phase: PhaseGameplay},
+				{name: "a", phase: PhaseGameplay, constraints: []Constraint{After("a")}},
+			},
+			want: "systems ordering cycle: a -> a",
+		},
+		{
+			name: "before and after each other",
+			systems: []system{
+				{name: "a", phase: PhaseCollision, constraints: []Constraint{After("b")}},
+				{name: "b", phase: PhaseCollision, constraints: []Constraint{After("a")}},
+			},
+			want: "systems ordering cycle: a -> b -> a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newScheduler(t, tt.systems...)
+
+			_, err := s.Systems()
+			if !errors.Is(err, ErrCycle) {
+				t.Fatalf("Systems error = %v, want %v", err, ErrCycle)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("Systems error = %q, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestConstraintsCannotReverseThePhases(t *testing.T) {
+	tests := []struct {
+		name    string
+		systems []system
+		want    string
+	}{
+		{
+			name: "before an earlier phase",
+			systems: []system{
+				{name: "input", phase: PhaseInput},
+				{name: "movement", phase: PhasePhysics, constraints: []Constraint{Before("input")}},
+			},
+			want: `systems ordering cycle: "movement" must run before "input" but its phase "physics" comes after "input"`,
+		},
+		{
+			name: "after a later phase",
+			systems: []system{
+				{name: "input", phase: PhaseInput, constraints: []Constraint{After("movement")}},
+				{name: "movement", phase: PhasePhysics},
+			},
+			want: `systems ordering cycle: "movement" must run before "input" but its phase "physics" comes after "input"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newScheduler(t, tt.systems...)
+
+			_, err := s.Systems()
+			if !errors.Is(err, ErrCycle) {
+				t.Fatalf("Systems error = %v, want %v", err, ErrCycle)
+			}
+			if err.Error() != tt.want {
+				t.Errorf("Systems error = %q, want %q", err, tt.want)
+			}
+		})
+	}
+
+	// Constraints that agree with the phases are fine
+	s := newScheduler(t,
+		system{name: "movement", phase: PhasePhysics, constraints: []Constraint{After("input")}},
+		system{name: "input", phase: PhaseInput, constraints: []Constraint{Before("movement")}},
+	)
+	checkOrder(t, s, "input", "movement")
+}
+
+func TestUnknownNames(t *testing.T) {
+	s := newScheduler(t, system{name: "movement", phase: PhasePhysics, constraints: []Constraint{After("input")}})
+
+	if _, err := s.Systems(); !errors.Is(err, ErrUnknownSystem) {
+		t.Errorf("Systems error = %v, want %v", err, ErrUnknownSystem)
+	}
+	if err := s.Enable("input"); !errors.Is(err, ErrUnknownSystem) {
+		t.Errorf("Enable error = %v, want %v", err, ErrUnknownSystem)
+	}
+	if err := s.Disable("input"); !errors.Is(err, ErrUnknownSystem) {
+		t.Errorf("Disable error = %v, want %v", err, ErrUnknownSystem)
+	}
+	if s.IsEnabled("input") {
+		t.Error("IsEnabled is true for an unknown system")
+	}
+
+	// Adding the missing system resolves the order again
+	if err := s.Add("input", PhaseInput, "input"); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	checkOrder(t, s, "input", "movement")
+
+	if err := s.Add("input", PhaseInput, "input"); err == nil {
+		t.Error("Add succeeded with a name already registered")
+	}
+	if err := s.Add("scenery", PhaseBackground, "scenery"); err == nil {
+		t.Error("Add succeeded with a phase the scheduler does not run")
+	}
+}
+
+func TestDisabledSystemsKeepTheirPlace(t *testing.T) {
+	s := newScheduler(t,
+		system{name: "input", phase: PhaseInput},
+		system{name: "patrol", phase: PhaseAI},
+		system{name: "movement", phase: PhasePhysics, constraints: []Constraint{After("patrol")}},
+		system{name: "collision", phase: PhaseCollision},
+	)
+
+	if err := s.Disable("patrol"); err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+	if err := s.Disable("input"); err != nil {
+		t.Fatalf("Disable: %v", err)
+	}
+	if s.IsEnabled("patrol") || !s.IsEnabled("movement") {
+		t.Errorf("IsEnabled patrol = %v, movement = %v, want false, true", s.IsEnabled("patrol"), s.IsEnabled("movement"))
+	}
+	checkOrder(t, s, "movement", "collision")
+
+	// Systems added while others are disabled are still ordered against them
+	if err := s.Add("steering", PhaseAI, "steering", Before("patrol")); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	checkOrder(t, s, "steering", "movement", "collision")
+
+	if err := s.Enable("patrol"); err != nil {
+		t.Fatalf("Enable: %v", err)
+	}
+	if err := s.Enable("input"); err != nil {
+		t.Fatalf("Enable: %v", err)
+	}
+	checkOrder(t, s, "input", "steering", "patrol", "movement", "collision")
+
+	// Enabling an enabled system changes nothing
+	if err := s.Enable("patrol"); err != nil {
+		t.Fatalf("Enable: %v", err)
+	}
+	checkOrder(t, s, "input", "steering", "patrol", "movement", "collision")
+}
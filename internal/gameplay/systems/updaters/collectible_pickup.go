@@ -1,8 +1,6 @@
 package updaters
 
 import (
-	"reflect"
-
 	"github.com/juanancid/maze-adventure/internal/core/components"
 	"github.com/juanancid/maze-adventure/internal/core/entities"
 	"github.com/juanancid/maze-adventure/internal/core/queries"
@@ -26,18 +24,19 @@ func (s *CollectiblePickupSystem) Update(world *entities.World, gameSession *ses
 		return
 	}
 
-	playerPos := world.GetComponent(playerEntity, reflect.TypeOf(&components.Position{})).(*components.Position)
-	playerSize := world.GetComponent(playerEntity, reflect.TypeOf(&components.Size{})).(*components.Size)
+	playerPos, hasPos := entities.Get[components.Position](world, playerEntity)
+	playerSize, hasSize := entities.Get[components.Size](world, playerEntity)
+	if !hasPos || !hasSize {
+		return
+	}
 
-	collectibles := world.Query(reflect.TypeOf(&components.Collectible{}), reflect.TypeOf(&components.Position{}), reflect.TypeOf(&components.Size{}))
-	for _, collectible := range collectibles {
-		cPos := world.GetComponent(collectible, reflect.TypeOf(&components.Position{})).(*components.Position)
-		cSize := world.GetComponent(collectible, reflect.TypeOf(&components.Size{})).(*components.Size)
-		cData := world.GetComponent(collectible, reflect.TypeOf(&components.Collectible{})).(*components.Collectible)
+	// Picked collectibles are destroyed when the world is flushed, the stores cannot change while iterating
+	for collectible, c := range entities.Query3[components.Collectible, components.Position, components.Size](world) {
+		cData, cPos, cSize := c.A, c.B, c.C
 
 		if intersects(playerPos, playerSize, cPos, cSize) && cData.Kind == components.CollectibleScore {
 			s.eventBus.Publish(events.CollectiblePicked{Value: cData.Value})
-			world.RemoveEntity(collectible)
+			world.Commands().Destroy(collectible)
 		}
 	}
 }
@@ -1,25 +1,22 @@
 package updaters
 
 import (
-	"math/rand"
-	"reflect"
-	"time"
-
 	"github.com/juanancid/maze-adventure/internal/core/components"
 	"github.com/juanancid/maze-adventure/internal/core/entities"
 	"github.com/juanancid/maze-adventure/internal/core/queries"
+	"github.com/juanancid/maze-adventure/internal/engine/clock"
 	"github.com/juanancid/maze-adventure/internal/gameplay/session"
 )
 
 // EnhancedPatrollerMovement handles advanced movement patterns for patroller NPCs
 type EnhancedPatrollerMovement struct {
-	startTime time.Time
+	clock clock.Clock
 }
 
-// NewEnhancedPatrollerMovement creates a new enhanced patroller movement system
-func NewEnhancedPatrollerMovement() EnhancedPatrollerMovement {
+// NewEnhancedPatrollerMovement creates a new enhanced patroller movement system timed by the given clock
+func NewEnhancedPatrollerMovement(clock clock.Clock) EnhancedPatrollerMovement {
 	return EnhancedPatrollerMovement{
-		startTime: time.Now(),
+		clock: clock,
 	}
 }
 
@@ -31,20 +28,8 @@ func (epm EnhancedPatrollerMovement) Update(world *entities.World, gameSession *
 	}
 
 	// Get all patroller entities with movement components
-	patrollerEntities := world.QueryComponents(&components.Patroller{}, &components.Position{}, &components.Velocity{})
-
-	for _, entity := range patrollerEntities {
-		patrollerComp := world.GetComponent(entity, reflect.TypeOf(&components.Patroller{}))
-		positionComp := world.GetComponent(entity, reflect.TypeOf(&components.Position{}))
-		velocityComp := world.GetComponent(entity, reflect.TypeOf(&components.Velocity{}))
-
-		if patrollerComp == nil || positionComp == nil || velocityComp == nil {
-			continue
-		}
-
-		patroller := patrollerComp.(*components.Patroller)
-		position := positionComp.(*components.Position)
-		velocity := velocityComp.(*components.Velocity)
+	for _, patrollerEntity := range entities.Query3[components.Patroller, components.Position, components.Velocity](world) {
+		patroller, position, velocity := patrollerEntity.A, patrollerEntity.B, patrollerEntity.C
 
 		// Only move active patrollers
 		if !patroller.IsPatrollerActive() {
@@ -60,7 +45,7 @@ func (epm EnhancedPatrollerMovement) Update(world *entities.World, gameSession *
 
 // applyEnhancedMovementPattern applies the appropriate movement pattern
 func (epm EnhancedPatrollerMovement) applyEnhancedMovementPattern(patroller *components.Patroller, position *components.Position, velocity *components.Velocity, maze *components.Maze) {
-	elapsed := time.Since(epm.startTime).Seconds()
+	elapsed := epm.clock.Now().Seconds()
 
 	switch patroller.PatrolType {
 	case components.PatrolPatternRandom:
@@ -81,7 +66,7 @@ func (epm EnhancedPatrollerMovement) applyEnhancedMovementPattern(patroller *com
 func (epm EnhancedPatrollerMovement) applyRandomMovement(patroller *components.Patroller, position *components.Position, velocity *components.Velocity, maze *components.Maze, elapsed float64) {
 	// Check if we should change direction (every 1-3 seconds randomly)
 	timeSinceLastChange := elapsed - patroller.State.LastDirectionChange
-	shouldChangeDirection := timeSinceLastChange > (1.0 + rand.Float64()*2.0)
+	shouldChangeDirection := timeSinceLastChange > (1.0 + patroller.NextRandom()*2.0)
 
 	if shouldChangeDirection || (velocity.DX == 0 && velocity.DY == 0) {
 		// Get current cell position
@@ -92,7 +77,7 @@ func (epm EnhancedPatrollerMovement) applyRandomMovement(patroller *components.P
 
 		if len(availableDirections) > 0 {
 			// Choose a random available direction
-			newDirection := availableDirections[rand.Intn(len(availableDirections))]
+			newDirection := availableDirections[patroller.NextRandomIntn(len(availableDirections))]
 			patroller.State.CurrentDirection = newDirection
 			patroller.State.LastDirectionChange = elapsed
 		}
@@ -143,7 +128,7 @@ func (epm EnhancedPatrollerMovement) applyCrossMovement(patroller *components.Pa
 	timeSinceLastChange := elapsed - patroller.State.LastDirectionChange
 
 	// Change between horizontal and vertical every 2-4 seconds
-	shouldChangePhase := timeSinceLastChange > (2.0 + rand.Float64()*2.0)
+	shouldChangePhase := timeSinceLastChange > (2.0 + patroller.NextRandom()*2.0)
 
 	if shouldChangePhase {
 		patroller.State.MovementPhase = (patroller.State.MovementPhase + 1) % 2
@@ -1,8 +1,6 @@
 package updaters
 
 import (
-	"reflect"
-
 	"github.com/juanancid/maze-adventure/internal/core/components"
 	"github.com/juanancid/maze-adventure/internal/core/entities"
 	"github.com/juanancid/maze-adventure/internal/core/queries"
@@ -32,10 +30,13 @@ func (ec ExitCollision) Update(w *entities.World, gameSession *session.GameSessi
 	}
 
 	// Get their components
-	exitPos := w.GetComponent(exitEntity, reflect.TypeOf(&components.Position{})).(*components.Position)
-	exitSize := w.GetComponent(exitEntity, reflect.TypeOf(&components.Size{})).(*components.Size)
-	playerPos := w.GetComponent(playerEntity, reflect.TypeOf(&components.Position{})).(*components.Position)
-	playerSize := w.GetComponent(playerEntity, reflect.TypeOf(&components.Size{})).(*components.Size)
+	exitPos, hasExitPos := entities.Get[components.Position](w, exitEntity)
+	exitSize, hasExitSize := entities.Get[components.Size](w, exitEntity)
+	playerPos, hasPlayerPos := entities.Get[components.Position](w, playerEntity)
+	playerSize, hasPlayerSize := entities.Get[components.Size](w, playerEntity)
+	if !hasExitPos || !hasExitSize || !hasPlayerPos || !hasPlayerSize {
+		return
+	}
 
 	// Check for collision
 	if checkCollision(exitPos, exitSize, playerPos, playerSize) {
@@ -1,8 +1,6 @@
 package updaters
 
 import (
-	"reflect"
-
 	"github.com/hajimehoshi/ebiten/v2"
 
 	"github.com/juanancid/maze-adventure/internal/core/components"
@@ -17,26 +15,11 @@ func NewInputControl() InputControl {
 }
 
 func (is InputControl) Update(world *entities.World, gameSession *session.GameSession) {
-	entitiesToControl := world.QueryComponents(&components.InputControlled{}, &components.Velocity{})
-	for _, entity := range entitiesToControl {
-		handlePlayerInput(world, entity, gameSession)
+	for _, controlledEntity := range entities.Query2[components.InputControlled, components.Velocity](world) {
+		updateVelocityFromInputWithGameSession(controlledEntity.A, controlledEntity.B, gameSession)
 	}
 }
 
-func handlePlayerInput(w *entities.World, entity entities.Entity, gameSession *session.GameSession) {
-	controlComp := w.GetComponent(entity, reflect.TypeOf(&components.InputControlled{}))
-	velocityComp := w.GetComponent(entity, reflect.TypeOf(&components.Velocity{}))
-
-	if controlComp == nil || velocityComp == nil {
-		return // Skip if components are missing
-	}
-
-	control := controlComp.(*components.InputControlled)
-	velocity := velocityComp.(*components.Velocity)
-
-	updateVelocityFromInputWithGameSession(control, velocity, gameSession)
-}
-
 func updateVelocityFromInputWithGameSession(control *components.InputControlled, vel *components.Velocity, gameSession *session.GameSession) {
 	// Update freeze state first (check if freeze duration has expired)
 	gameSession.UpdateFreezeState()
@@ -3,6 +3,7 @@ package updaters
 import (
 	"github.com/juanancid/maze-adventure/internal/core/components"
 	"github.com/juanancid/maze-adventure/internal/core/entities"
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
 	"github.com/juanancid/maze-adventure/internal/core/queries"
 	"github.com/juanancid/maze-adventure/internal/gameplay/events"
 	"github.com/juanancid/maze-adventure/internal/gameplay/session"
@@ -27,13 +28,12 @@ func (mc MazeCollision) Update(world *entities.World, gameSession *session.GameS
 		return
 	}
 
-	entityList := world.QueryComponents(&components.Position{}, &components.Size{}, &components.Velocity{})
-	for _, entity := range entityList {
-		pos := entityList.GetPosition(world, entity)
-		size := entityList.GetSize(world, entity)
-		vel := entityList.GetVelocity(world, entity)
-
-		enforcePlayerMazeCollisions(pos, size, vel, gameSession, maze, mc.eventBus)
+	for entity, movingEntity := range entities.Query3[components.Position, components.Size, components.Velocity](world) {
+		// Patrollers move too, their walls are enforced by PatrollerMazeCollision
+		if !entities.Has[components.InputControlled](world, entity) {
+			continue
+		}
+		enforcePlayerMazeCollisions(movingEntity.A, movingEntity.B, movingEntity.C, gameSession, maze, mc.eventBus)
 	}
 }
 
@@ -54,7 +54,7 @@ func enforcePlayerMazeCollisions(pos *components.Position, size *components.Size
 	// Check if player has moved to a different cell
 	if gameSession.HasCellChanged(col, row) {
 		gameSession.SetCell(col, row)
-
+		eventBus.Publish(events.PlayerEnteredCell{Col: col, Row: row})
 	}
 
 	// Handle wall collisions
@@ -64,7 +64,7 @@ func enforcePlayerMazeCollisions(pos *components.Position, size *components.Size
 
 		if cell.IsFreezing() && gameSession.CanApplyFreezeEffect() {
 			// Emit freeze event when entering freezing cell
-			eventBus.Publish(events.PlayerFrozen{Duration: int(session.DefaultFreezeDuration / time.Millisecond)})
+			eventBus.Publish(events.PlayerFrozen{Duration: int(gameSession.Config.Difficulty.FreezeDuration / time.Millisecond)})
 		}
 		if cell.IsDeadly() && gameSession.CanApplyDamageEffect() {
 			// Emit damage event when entering deadly cell (with cooldown check)
@@ -80,7 +80,7 @@ func convertWorldPositionToCellCoordinates(x, y, cellWidth, cellHeight float64)
 }
 
 // isCellWithinMazeBounds checks if the cell coordinates are within the maze bounds
-func isCellWithinMazeBounds(layout components.Layout, col, row int) bool {
+func isCellWithinMazeBounds(layout mazelayout.Layout, col, row int) bool {
 	return col >= 0 && col < layout.Cols() && row >= 0 && row < layout.Rows()
 }
 
@@ -1,30 +1,40 @@
 package updaters
 
 import (
-	"reflect"
+	"time"
 
 	"github.com/juanancid/maze-adventure/internal/core/components"
 	"github.com/juanancid/maze-adventure/internal/core/entities"
+	"github.com/juanancid/maze-adventure/internal/engine/clock"
+	"github.com/juanancid/maze-adventure/internal/engine/config"
 	"github.com/juanancid/maze-adventure/internal/gameplay/session"
 )
 
-type Movement struct{}
+// tickDuration is the simulation time of a tick at normal speed
+const tickDuration = time.Second / config.TicksPerSecond
 
-func NewMovement() Movement {
-	return Movement{}
+// Movement moves entities by their velocity, which is in pixels per tick at normal speed
+type Movement struct {
+	clock clock.Clock
 }
 
-func (ms Movement) Update(wold *entities.World, gameSession *session.GameSession) {
-	entitiesToMove := wold.QueryComponents(&components.Velocity{}, &components.Position{})
-	for _, entity := range entitiesToMove {
-		moveEntity(wold, entity)
+// NewMovement creates a movement system moving entities as far as the simulation time of the tick allows
+func NewMovement(clock clock.Clock) Movement {
+	return Movement{
+		clock: clock,
 	}
 }
 
-func moveEntity(w *entities.World, entity entities.Entity) {
-	pos := w.GetComponent(entity, reflect.TypeOf(&components.Position{})).(*components.Position)
-	vel := w.GetComponent(entity, reflect.TypeOf(&components.Velocity{})).(*components.Velocity)
+func (ms Movement) Update(wold *entities.World, gameSession *session.GameSession) {
+	// Ticks at normal speed the simulation time of this tick is worth, 0 while the clock is paused
+	ticks := float64(ms.clock.Delta()) / float64(tickDuration)
+
+	for _, movingEntity := range entities.Query2[components.Velocity, components.Position](wold) {
+		moveEntity(movingEntity.B, movingEntity.A, ticks)
+	}
+}
 
-	pos.X += vel.DX
-	pos.Y += vel.DY
+func moveEntity(pos *components.Position, vel *components.Velocity, ticks float64) {
+	pos.X += vel.DX * ticks
+	pos.Y += vel.DY * ticks
 }
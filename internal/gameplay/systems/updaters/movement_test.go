@@ -0,0 +1,67 @@
+package updaters
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/juanancid/maze-adventure/internal/core/components"
+	"github.com/juanancid/maze-adventure/internal/core/entities"
+	"github.com/juanancid/maze-adventure/internal/engine/clock"
+	"github.com/juanancid/maze-adventure/internal/gameplay/config"
+	"github.com/juanancid/maze-adventure/internal/gameplay/events"
+	"github.com/juanancid/maze-adventure/internal/gameplay/session"
+)
+
+func TestMovementFollowsTheClock(t *testing.T) {
+	tests := []struct {
+		name  string
+		delta time.Duration
+		want  float64
+	}{
+		{name: "normal speed", delta: tickDuration, want: 2},
+		{name: "half speed", delta: tickDuration / 2, want: 1},
+		{name: "double speed", delta: 2 * tickDuration, want: 4},
+		{name: "paused", delta: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			world := entities.NewWorld()
+			entity := world.NewEntity()
+			position := &components.Position{}
+			entities.Add(world, entity, position)
+			entities.Add(world, entity, &components.Velocity{DX: 2, DY: -2})
+
+			fake := clock.NewFake()
+			fake.Advance(tt.delta)
+			NewMovement(fake).Update(world, nil)
+
+			if math.Abs(position.X-tt.want) > 1e-6 || math.Abs(position.Y+tt.want) > 1e-6 {
+				t.Errorf("moved to (%v,%v), want (%v,%v)", position.X, position.Y, tt.want, -tt.want)
+			}
+		})
+	}
+}
+
+func TestTimerExpiresWithTheClock(t *testing.T) {
+	fake := clock.NewFake()
+	gameSession := session.NewGameSession(config.GameConfig{Difficulty: config.Normal(), Seed: 1}, fake)
+	gameSession.SetTimer(1)
+
+	bus := events.NewBus()
+	expired := 0
+	bus.Subscribe(reflect.TypeOf(events.TimerExpired{}), func(events.Event) { expired++ })
+
+	timer := NewTimer(bus, fake)
+	for range 3 {
+		fake.Advance(500 * time.Millisecond)
+		timer.Update(nil, gameSession)
+		bus.Process()
+	}
+
+	if expired != 1 {
+		t.Errorf("timer expired %d times, want once", expired)
+	}
+}
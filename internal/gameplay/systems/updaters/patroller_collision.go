@@ -1,8 +1,6 @@
 package updaters
 
 import (
-	"reflect"
-
 	"github.com/juanancid/maze-adventure/internal/core/components"
 	"github.com/juanancid/maze-adventure/internal/core/entities"
 	"github.com/juanancid/maze-adventure/internal/gameplay/events"
@@ -24,38 +22,12 @@ func NewPatrollerCollision(eventBus *events.Bus) PatrollerCollision {
 // Update checks for collisions between player and patrollers
 func (pc PatrollerCollision) Update(world *entities.World, gameSession *session.GameSession) {
 	// Get all player entities (entities with InputControlled component indicate player)
-	playerEntities := world.QueryComponents(&components.Position{}, &components.Size{}, &components.InputControlled{})
-	if len(playerEntities) == 0 {
-		return // No player found
-	}
-
-	// Get all patroller entities
-	patrollerEntities := world.QueryComponents(&components.Patroller{}, &components.Position{}, &components.Size{})
-
-	// Check collisions between player and each patroller
-	for _, playerEntity := range playerEntities {
-		playerPos := world.GetComponent(playerEntity, reflect.TypeOf(&components.Position{}))
-		playerSize := world.GetComponent(playerEntity, reflect.TypeOf(&components.Size{}))
-
-		if playerPos == nil || playerSize == nil {
-			continue
-		}
-
-		playerPosition := playerPos.(*components.Position)
-		playerSizeComp := playerSize.(*components.Size)
-
-		for _, patrollerEntity := range patrollerEntities {
-			patrollerComp := world.GetComponent(patrollerEntity, reflect.TypeOf(&components.Patroller{}))
-			patrollerPos := world.GetComponent(patrollerEntity, reflect.TypeOf(&components.Position{}))
-			patrollerSize := world.GetComponent(patrollerEntity, reflect.TypeOf(&components.Size{}))
-
-			if patrollerComp == nil || patrollerPos == nil || patrollerSize == nil {
-				continue
-			}
+	for _, player := range entities.Query3[components.Position, components.Size, components.InputControlled](world) {
+		playerPosition, playerSizeComp := player.A, player.B
 
-			patroller := patrollerComp.(*components.Patroller)
-			patrollerPosition := patrollerPos.(*components.Position)
-			patrollerSizeComp := patrollerSize.(*components.Size)
+		// Check collisions between player and each patroller
+		for _, patrollerEntity := range entities.Query3[components.Patroller, components.Position, components.Size](world) {
+			patroller, patrollerPosition, patrollerSizeComp := patrollerEntity.A, patrollerEntity.B, patrollerEntity.C
 
 			// Only check collision with active patrollers
 			if !patroller.IsPatrollerActive() {
@@ -1,10 +1,9 @@
 package updaters
 
 import (
-	"reflect"
-
 	"github.com/juanancid/maze-adventure/internal/core/components"
 	"github.com/juanancid/maze-adventure/internal/core/entities"
+	"github.com/juanancid/maze-adventure/internal/core/mazelayout"
 	"github.com/juanancid/maze-adventure/internal/core/queries"
 	"github.com/juanancid/maze-adventure/internal/gameplay/session"
 )
@@ -26,23 +25,14 @@ func (pmc PatrollerMazeCollision) Update(world *entities.World, gameSession *ses
 	}
 
 	// Get all patroller entities with position, size, and velocity
-	patrollerEntities := world.QueryComponents(&components.Patroller{}, &components.Position{}, &components.Size{}, &components.Velocity{})
-
-	for _, entity := range patrollerEntities {
-		patrollerComp := world.GetComponent(entity, reflect.TypeOf(&components.Patroller{}))
-		positionComp := world.GetComponent(entity, reflect.TypeOf(&components.Position{}))
-		sizeComp := world.GetComponent(entity, reflect.TypeOf(&components.Size{}))
-		velocityComp := world.GetComponent(entity, reflect.TypeOf(&components.Velocity{}))
+	for entity, patrollerEntity := range entities.Query3[components.Patroller, components.Position, components.Velocity](world) {
+		patroller, position, velocity := patrollerEntity.A, patrollerEntity.B, patrollerEntity.C
 
-		if patrollerComp == nil || positionComp == nil || sizeComp == nil || velocityComp == nil {
+		size, hasSize := entities.Get[components.Size](world, entity)
+		if !hasSize {
 			continue
 		}
 
-		patroller := patrollerComp.(*components.Patroller)
-		position := positionComp.(*components.Position)
-		size := sizeComp.(*components.Size)
-		velocity := velocityComp.(*components.Velocity)
-
 		// Only handle collision for active patrollers
 		if !patroller.IsPatrollerActive() {
 			continue
@@ -80,7 +70,7 @@ func (pmc PatrollerMazeCollision) convertWorldPositionToCellCoordinates(x, y, ce
 }
 
 // isCellWithinMazeBounds checks if the given cell coordinates are within maze bounds
-func (pmc PatrollerMazeCollision) isCellWithinMazeBounds(layout components.Layout, col, row int) bool {
+func (pmc PatrollerMazeCollision) isCellWithinMazeBounds(layout mazelayout.Layout, col, row int) bool {
 	return col >= 0 && col < layout.Cols() && row >= 0 && row < layout.Rows()
 }
 
@@ -2,30 +2,35 @@ package updaters
 
 import (
 	"github.com/juanancid/maze-adventure/internal/core/entities"
+	"github.com/juanancid/maze-adventure/internal/engine/clock"
 	"github.com/juanancid/maze-adventure/internal/gameplay/events"
 	"github.com/juanancid/maze-adventure/internal/gameplay/session"
 )
 
-// Timer handles the level timer countdown
+// Timer handles the level timer countdown and the run timer of time-attack runs
 type Timer struct {
 	eventBus *events.Bus
+	clock    clock.Clock
 }
 
-// NewTimer creates a new timer updater
-func NewTimer(eventBus *events.Bus) *Timer {
+// NewTimer creates a new timer updater counting down simulation time
+func NewTimer(eventBus *events.Bus, clock clock.Clock) *Timer {
 	return &Timer{
 		eventBus: eventBus,
+		clock:    clock,
 	}
 }
 
 // Update decreases the timer and publishes timer expired event when needed
 func (t *Timer) Update(world *entities.World, gameSession *session.GameSession) {
+	// Simulation time of this tick, 0 while the clock is paused
+	delta := t.clock.Delta()
+	gameSession.UpdateRunTimer(delta)
+
 	if !gameSession.TimerEnabled {
 		return
 	}
-
-	// Get delta time from Ebiten (1/60 for 60 FPS)
-	deltaTime := 1.0 / 60.0
+	deltaTime := delta.Seconds()
 
 	// Store previous state to detect expiration
 	wasExpired := gameSession.IsTimerExpired()